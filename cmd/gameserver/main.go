@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
@@ -15,14 +17,24 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
+	"quark/gameserver"
 	quark_grpc "quark/grpc"
 	"quark/proto"
 )
 
 var addr string
+var roomServerOpts quark_grpc.RoomServerOptions
+var shutdownTimeout time.Duration
 
 func init() {
 	flag.StringVar(&addr, "b", "127.0.0.1:20000", "The gameserver gRPC binding address")
+	flag.DurationVar(&roomServerOpts.PingInterval, "ping-interval", 5*time.Second, "The interval of pings to clients")
+	flag.DurationVar(&roomServerOpts.IdleTimeout, "idle-timeout", 30*time.Second, "Remove clients which have sent nothing for the duration")
+	flag.DurationVar(&roomServerOpts.AFKTimeout, "afk-timeout", 0, "Remove clients which have sent no commands for the duration (0 disables)")
+	flag.DurationVar(&roomServerOpts.BatchWindow, "batch-window", 0, "Send messages to clients in batches collected for the duration (0 disables)")
+	flag.IntVar(&roomServerOpts.BatchSize, "batch-size", 64, "The max number of messages in a batch")
+	flag.DurationVar(&roomServerOpts.ReservationTTL, "reservation-ttl", gameserver.DefaultReservationTTL, "Release the seats reserved for users who have not joined in the duration")
+	flag.DurationVar(&shutdownTimeout, "shutdown-timeout", 10*time.Second, "The time to wait for clients to disconnect on shutdown")
 }
 
 func main() {
@@ -55,7 +67,8 @@ func main() {
 	}
 	grpcServer := grpc.NewServer(opts...)
 	proto.RegisterHealthServer(grpcServer, new(quark_grpc.HealthServer))
-	proto.RegisterRoomServer(grpcServer, quark_grpc.NewRoomServer())
+	roomSet := gameserver.NewRoomSet()
+	proto.RegisterRoomServer(grpcServer, quark_grpc.NewRoomServer(roomSet, roomServerOpts))
 
 	go func() {
 		log.Printf("gRPC service listen at %s", addr)
@@ -66,6 +79,21 @@ func main() {
 
 	<-sig
 
-	grpcServer.GracefulStop()
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	if err := roomSet.CloseAll(ctx, gameserver.LeaveReasonServerShutdown, ""); err != nil {
+		log.Printf("failed to close rooms: %v", err)
+	}
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		grpcServer.Stop()
+	}
 	log.Println("gRPC server shutdown")
 }
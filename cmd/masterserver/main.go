@@ -1,12 +1,14 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
@@ -22,14 +24,40 @@ import (
 
 var addr string
 var internalAddr string
+var lobbyServerOpts quark_grpc.LobbyServerOptions
+var matchRulesPath string
+var matchmakerOpts masterserver.MatchmakerOptions
+var reservationTTL time.Duration
 
 func init() {
 	flag.StringVar(&addr, "b", "127.0.0.1:20000", "The lobby application binding address for client")
 	flag.StringVar(&internalAddr, "i", "127.0.0.1:50000", "The masterserver gRPC binding address for gameserver")
+	flag.DurationVar(&lobbyServerOpts.StatsInterval, "stats-interval", 5*time.Second, "The interval of stats sent to clients in lobbies (0 disables)")
+	flag.StringVar(&matchRulesPath, "match-rules", "", "The JSON file of the match rules by mode")
+	flag.DurationVar(&reservationTTL, "reservation-ttl", masterserver.DefaultReservationTTL, "Release the seats reserved for users who have not joined in the duration")
+	flag.DurationVar(&matchmakerOpts.Interval, "match-interval", masterserver.DefaultMatchInterval, "The interval of forming matches")
+}
+
+func loadMatchRules(path string) (map[string]masterserver.MatchRules, error) {
+	rules := make(map[string]masterserver.MatchRules)
+	if path == "" {
+		return rules, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(&rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
 }
 
 func main() {
 	flag.Parse()
+	lobbyServerOpts.ReservationTTL = reservationTTL
+	matchmakerOpts.ReservationTTL = reservationTTL
 
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGTERM, os.Interrupt)
@@ -54,10 +82,20 @@ func main() {
 
 	fleet := masterserver.NewFleet()
 
+	matchmakerOpts.Rules, err = loadMatchRules(matchRulesPath)
+	if err != nil {
+		log.Fatalf("failed to load match rules: %v", err)
+	}
+	matchmaker, err := masterserver.NewMatchmaker(fleet, matchmakerOpts)
+	if err != nil {
+		log.Fatalf("failed to create matchmaker: %v", err)
+	}
+
 	// for client
 	grpcLobbyServer := grpc.NewServer(opts...)
 	{
-		proto.RegisterLobbyServer(grpcLobbyServer, quark_grpc.NewLobbyServer(fleet))
+		proto.RegisterLobbyServer(grpcLobbyServer, quark_grpc.NewLobbyServer(fleet, lobbyServerOpts))
+		proto.RegisterMatchmakerServer(grpcLobbyServer, quark_grpc.NewMatchmakerServer(matchmaker))
 
 		lis, err := net.Listen("tcp", addr)
 		if err != nil {
@@ -93,6 +131,7 @@ func main() {
 	<-sig
 
 	grpcMasterServer.GracefulStop()
+	matchmaker.Stop()
 	grpcLobbyServer.GracefulStop()
 
 	log.Println("gRPC server shutdown")
@@ -82,34 +82,49 @@ func main() {
 				log.Fatalf("Failed to receive a note : %v", err)
 			}
 
-			switch ev := in.Event.(type) {
-			case *proto.ServerMessage_OnJoinRoomSuccess:
-				id := ev.OnJoinRoomSuccess.ActorID
-				fmt.Printf("You are %s\n", id)
-
-				fmt.Printf("%s > ", id)
-				actorID.Store(id)
-
-				joined <- struct{}{}
-			case *proto.ServerMessage_OnLeaveRoomSuccess:
-				fmt.Println("bye.")
-				os.Exit(0)
-			case *proto.ServerMessage_OnMessageReceived:
-				recvMsg := ev.OnMessageReceived
-				cmd := parseCmd(recvMsg.SenderID, recvMsg.Message.Code, recvMsg.Message.Payload)
-				cmd.display()
-
-				switch cmd.(type) {
-				case *ping:
-					msg := pongCmd()
-					if err := sendMessage(stream, msg); err != nil {
-						log.Fatalf("Failed to leave room: %v", err)
+			events := []*proto.ServerMessage{in}
+			if ev, ok := in.Event.(*proto.ServerMessage_OnBatch); ok {
+				events = ev.OnBatch.Messages
+			}
+
+			for _, in := range events {
+				switch ev := in.Event.(type) {
+				case *proto.ServerMessage_OnJoinRoomSuccess:
+					id := ev.OnJoinRoomSuccess.ActorID
+					fmt.Printf("You are %s\n", id)
+
+					fmt.Printf("%s > ", id)
+					actorID.Store(id)
+
+					joined <- struct{}{}
+				case *proto.ServerMessage_OnPing:
+					if err := stream.Send(&proto.ClientMessage{
+						Command: &proto.ClientMessage_Pong{
+							Pong: &proto.ClientMessage_PongCommand{PingID: ev.OnPing.PingID},
+						},
+					}); err != nil {
+						log.Fatalf("Failed to send pong: %v", err)
+					}
+				case *proto.ServerMessage_OnLeaveRoomSuccess:
+					fmt.Println("bye.")
+					os.Exit(0)
+				case *proto.ServerMessage_OnMessageReceived:
+					recvMsg := ev.OnMessageReceived
+					cmd := parseCmd(recvMsg.SenderID, recvMsg.Message.Code, recvMsg.Message.Payload)
+					cmd.display()
+
+					switch cmd.(type) {
+					case *ping:
+						msg := pongCmd()
+						if err := sendMessage(stream, roomID, msg); err != nil {
+							log.Fatalf("Failed to leave room: %v", err)
+						}
+						p := pong{actorID: actorID.Load()}
+						p.display()
 					}
-					p := pong{actorID: actorID.Load()}
-					p.display()
-				}
 
-				fmt.Printf("%s > ", actorID.Load())
+					fmt.Printf("%s > ", actorID.Load())
+				}
 			}
 		}
 	}()
@@ -123,6 +138,7 @@ func main() {
 		switch text {
 		case "!exit":
 			if err := stream.Send(&proto.ClientMessage{
+				RoomID: roomID,
 				Command: &proto.ClientMessage_LeaveRoom{
 					LeaveRoom: &proto.ClientMessage_LeaveRoomCommand{},
 				},
@@ -131,13 +147,13 @@ func main() {
 			}
 		case "!ping":
 			msg := pingCmd()
-			if err := sendMessage(stream, msg); err != nil {
+			if err := sendMessage(stream, roomID, msg); err != nil {
 				log.Fatalf("Failed to leave room: %v", err)
 			}
 			fmt.Printf("%s > ", actorID.Load())
 		default:
 			msg := textCmd(text)
-			if err := sendMessage(stream, msg); err != nil {
+			if err := sendMessage(stream, roomID, msg); err != nil {
 				log.Fatalf("Failed to leave room: %v", err)
 			}
 			fmt.Printf("%s > ", actorID.Load())
@@ -206,8 +222,9 @@ func parseCmd(senderID string, code uint32, payload []byte) cmd {
 	}
 }
 
-func sendMessage(stream proto.Room_ServiceClient, m *proto.Message) error {
+func sendMessage(stream proto.Room_ServiceClient, roomID uint64, m *proto.Message) error {
 	return stream.Send(&proto.ClientMessage{
+		RoomID: roomID,
 		Command: &proto.ClientMessage_SendMessage{
 			SendMessage: &proto.ClientMessage_SendMessageCommand{
 				Message: m,
@@ -1,14 +1,22 @@
 package gameserver
 
 import (
+	"context"
 	"sync"
+	"time"
+
+	"quark"
 )
 
+// Actor can be a member of several rooms at once.
+// Operations on a room take the ID of the room.
 type Actor struct {
-	id ActorID
+	id   ActorID
+	bot  bool
+	user string
 
-	re *RoomEntry
-	mu sync.RWMutex
+	rooms map[quark.RoomID]*RoomEntry
+	mu    sync.RWMutex
 }
 
 type Payload struct {
@@ -16,67 +24,218 @@ type Payload struct {
 	Body []byte
 }
 
+// MessageOptions are optional for the messages an actor sends; only the first one is used.
+type MessageOptions struct {
+	// CoalesceKey is used by broadcasts, see ActorMessage
+	CoalesceKey string
+	// CorrelationID is passed on to ActorMessage or RequestMessage
+	CorrelationID uint64
+	// Ack is used by broadcasts, see ActorMessage
+	Ack bool
+}
+
+func firstOptions(opts []MessageOptions) MessageOptions {
+	if len(opts) == 0 {
+		return MessageOptions{}
+	}
+	return opts[0]
+}
+
 func NewActor() *Actor {
 	actorID := NewActorID()
-	return &Actor{id: actorID}
+	return &Actor{id: actorID, rooms: make(map[quark.RoomID]*RoomEntry)}
+}
+
+// NewBot returns an actor which is driven by server-side code instead of a client.
+func NewBot() *Actor {
+	a := NewActor()
+	a.bot = true
+	return a
+}
+
+// NewActorForUser returns an actor on behalf of the user, which room interceptors can see.
+func NewActorForUser(userID string) *Actor {
+	a := NewActor()
+	a.user = userID
+	return a
 }
 
 func (a *Actor) ActorID() ActorID {
 	return a.id
 }
 
-func (a *Actor) JoinTo(r *Room) {
-	e := r.NewEntry(a.id)
+func (a *Actor) IsBot() bool {
+	return a.bot
+}
+
+func (a *Actor) UserID() string {
+	return a.user
+}
+
+func (a *Actor) JoinTo(ctx context.Context, r *Room) error {
+	// an entry which was removed by the room, e.g. kicked, can be replaced
+	if e := a.roomEntry(r.ID()); e != nil && e.check(ctx) == nil {
+		return ErrAlreadyMember
+	}
+	e, err := r.newEntry(ctx, roomJoinCmd{actorID: a.id, bot: a.bot, user: a.user})
+	if err != nil {
+		return err
+	}
 
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	a.re = e
+	a.rooms[r.ID()] = e
+	return nil
 }
 
-func (a *Actor) roomEntry() *RoomEntry {
+func (a *Actor) roomEntry(roomID quark.RoomID) *RoomEntry {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
-	return a.re
+	return a.rooms[roomID]
+}
+
+func (a *Actor) Leave(ctx context.Context, roomID quark.RoomID) error {
+	return a.LeaveWithReason(ctx, roomID, LeaveReasonVoluntary, "")
 }
 
-func (a *Actor) Leave() bool {
-	e := a.roomEntry()
+// LeaveWithReason removes the actor from the room.
+// The actor is no longer in the room afterwards even if an error is returned.
+func (a *Actor) LeaveWithReason(ctx context.Context, roomID quark.RoomID, reason LeaveReason, detail string) error {
+	e := a.roomEntry(roomID)
 	if e == nil {
-		return false
+		return ErrNotMember
 	}
 
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	e.Leave()
-	a.re = nil
-	return true
+	err := e.Leave(ctx, reason, detail)
+	delete(a.rooms, roomID)
+	return err
+}
+
+// LeaveAll removes the actor from every room it is in.
+func (a *Actor) LeaveAll(ctx context.Context, reason LeaveReason, detail string) {
+	for _, id := range a.Rooms() {
+		a.LeaveWithReason(ctx, id, reason, detail)
+	}
 }
 
-func (a *Actor) BroadcastToRoom(p Payload) bool {
-	e := a.roomEntry()
+func (a *Actor) BroadcastToRoom(ctx context.Context, roomID quark.RoomID, p Payload, opts ...MessageOptions) error {
+	e := a.roomEntry(roomID)
 	if e == nil {
-		return false
+		return ErrNotMember
 	}
-	e.Send(ActorMessage{
-		Sender:  a.id,
-		Code:    p.Code,
-		Payload: p.Body,
+	o := firstOptions(opts)
+	return e.Send(ctx, ActorMessage{
+		Sender:        a.id,
+		Code:          p.Code,
+		Payload:       p.Body,
+		CoalesceKey:   o.CoalesceKey,
+		CorrelationID: o.CorrelationID,
+		Ack:           o.Ack,
 	})
-	return true
 }
 
-func (a *Actor) Inbox() <-chan Message {
-	e := a.roomEntry()
+func (a *Actor) BroadcastToTeam(ctx context.Context, roomID quark.RoomID, p Payload, opts ...MessageOptions) error {
+	e := a.roomEntry(roomID)
+	if e == nil {
+		return ErrNotMember
+	}
+	o := firstOptions(opts)
+	return e.Send(ctx, ActorMessage{
+		Sender:        a.id,
+		Code:          p.Code,
+		Payload:       p.Body,
+		TeamOnly:      true,
+		CoalesceKey:   o.CoalesceKey,
+		CorrelationID: o.CorrelationID,
+		Ack:           o.Ack,
+	})
+}
+
+// Request sends a request to the target actor, or to the room itself if target is empty.
+// The result arrives in the inbox of the room as a ResponseMessage.
+func (a *Actor) Request(ctx context.Context, roomID quark.RoomID, target ActorID, requestID uint64, p Payload, timeout time.Duration, opts ...MessageOptions) error {
+	e := a.roomEntry(roomID)
+	if e == nil {
+		return ErrNotMember
+	}
+	return e.Request(ctx, RequestMessage{
+		Sender:    a.id,
+		Target:    target,
+		RequestID: requestID,
+		Code:      p.Code,
+		Payload:   p.Body,
+		Timeout:   timeout,
+
+		CorrelationID: firstOptions(opts).CorrelationID,
+	})
+}
+
+func (a *Actor) Respond(ctx context.Context, roomID quark.RoomID, requester ActorID, requestID uint64, p Payload) error {
+	e := a.roomEntry(roomID)
+	if e == nil {
+		return ErrNotMember
+	}
+	return e.Respond(ctx, ResponseMessage{
+		Sender:    a.id,
+		Requester: requester,
+		RequestID: requestID,
+		Code:      p.Code,
+		Payload:   p.Body,
+	})
+}
+
+func (a *Actor) ChangeTeam(ctx context.Context, roomID quark.RoomID, team TeamID) error {
+	e := a.roomEntry(roomID)
+	if e == nil {
+		return ErrNotMember
+	}
+	return e.ChangeTeam(ctx, team)
+}
+
+func (a *Actor) SwapTeams(ctx context.Context, roomID quark.RoomID, x, y ActorID) error {
+	e := a.roomEntry(roomID)
+	if e == nil {
+		return ErrNotMember
+	}
+	return e.SwapTeams(ctx, x, y)
+}
+
+// SetProperties merges props into the properties of the actor in the room, which are visible to other members.
+func (a *Actor) SetProperties(ctx context.Context, roomID quark.RoomID, props map[string]string) error {
+	e := a.roomEntry(roomID)
+	if e == nil {
+		return ErrNotMember
+	}
+	return e.SetProperties(ctx, props)
+}
+
+// Inbox returns the messages from the room; it is closed if the actor is not in the room.
+func (a *Actor) Inbox(roomID quark.RoomID) <-chan Message {
+	e := a.roomEntry(roomID)
 	if e == nil {
 		c := make(chan Message)
 		close(c)
 		return c
 	}
-	return a.roomEntry().Subscription()
+	return e.Subscription()
 }
 
-func (a *Actor) InRoom() bool {
-	return a.roomEntry() != nil
+func (a *Actor) InRoom(roomID quark.RoomID) bool {
+	return a.roomEntry(roomID) != nil
+}
+
+// Rooms returns the IDs of the rooms the actor is in.
+func (a *Actor) Rooms() []quark.RoomID {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	ids := make([]quark.RoomID, 0, len(a.rooms))
+	for id := range a.rooms {
+		ids = append(ids, id)
+	}
+	return ids
 }
 
 func (a *Actor) IsOwnMessage(m *ActorMessage) bool {
@@ -8,31 +8,33 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"quark"
 )
 
 func TestActor_Leave(t *testing.T) {
-	r := NewRoom()
+	r := NewRoom(RoomOptions{})
 	defer r.Stop()
 
+	ctx := context.Background()
 	a := NewActor()
 
-	a.JoinTo(r)
-	require.True(t, a.InRoom())
+	require.NoError(t, a.JoinTo(ctx, r))
+	require.True(t, a.InRoom(r.ID()))
 
-	ok := a.Leave()
-	assert.True(t, ok)
+	assert.NoError(t, a.Leave(ctx, r.ID()))
 
-	assert.False(t, a.InRoom())
+	assert.False(t, a.InRoom(r.ID()))
 
-	_, ok = <-a.Inbox()
+	_, ok := <-a.Inbox(r.ID())
 	assert.False(t, ok)
 
-	ok = a.Leave()
-	assert.False(t, ok)
+	assert.Equal(t, ErrNotMember, a.Leave(ctx, r.ID()))
+	assert.Equal(t, ErrNotMember, a.BroadcastToRoom(ctx, r.ID(), Payload{0x01, nil}))
 }
 
 func TestActor_BroadcastToRoom(t *testing.T) {
-	r := NewRoom()
+	r := NewRoom(RoomOptions{})
 	defer r.Stop()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
@@ -42,28 +44,28 @@ func TestActor_BroadcastToRoom(t *testing.T) {
 	a2 := NewActor()
 	a3 := NewActor()
 
-	a1.JoinTo(r)
-	a2.JoinTo(r)
+	a1.JoinTo(ctx, r)
+	a2.JoinTo(ctx, r)
 	{
-		m := <-a1.Inbox()
+		m := <-a1.Inbox(r.ID())
 		require.IsType(t, m, JoinRoomEvent{})
 		assert.Len(t, m.(JoinRoomEvent).ActorList, 2)
 	}
-	a3.JoinTo(r)
+	a3.JoinTo(ctx, r)
 	{
-		m := <-a1.Inbox()
+		m := <-a1.Inbox(r.ID())
 		require.IsType(t, m, JoinRoomEvent{})
 		assert.Len(t, m.(JoinRoomEvent).ActorList, 3)
 	}
 	{
-		m := <-a2.Inbox()
+		m := <-a2.Inbox(r.ID())
 		require.IsType(t, m, JoinRoomEvent{})
 		assert.Len(t, m.(JoinRoomEvent).ActorList, 3)
 	}
 
 	body := make([]byte, 1024)
 	rand.Read(body)
-	a3.BroadcastToRoom(Payload{0x01, body})
+	a3.BroadcastToRoom(ctx, r.ID(), Payload{0x01, body})
 
 	n := 0
 L:
@@ -72,21 +74,21 @@ L:
 		case <-ctx.Done():
 			t.Fatal(ctx.Err())
 			return
-		case m := <-a1.Inbox():
+		case m := <-a1.Inbox(r.ID()):
 			require.IsType(t, m, ActorMessage{})
 			am := m.(ActorMessage)
 			assert.Equal(t, a3.ActorID(), am.Sender)
 			assert.EqualValues(t, 0x01, am.Code)
 			assert.Equal(t, body, am.Payload)
 			n += 1
-		case m := <-a2.Inbox():
+		case m := <-a2.Inbox(r.ID()):
 			require.IsType(t, m, ActorMessage{})
 			am := m.(ActorMessage)
 			assert.Equal(t, a3.ActorID(), am.Sender)
 			assert.EqualValues(t, 0x01, am.Code)
 			assert.Equal(t, body, am.Payload)
 			n += 1
-		case m := <-a3.Inbox():
+		case m := <-a3.Inbox(r.ID()):
 			require.IsType(t, m, ActorMessage{})
 			am := m.(ActorMessage)
 			assert.Equal(t, a3.ActorID(), am.Sender)
@@ -100,21 +102,21 @@ L:
 		}
 	}
 
-	a2.Leave()
+	a2.Leave(ctx, r.ID())
 	{
-		m := <-a1.Inbox()
+		m := <-a1.Inbox(r.ID())
 		require.IsType(t, m, LeaveRoomEvent{})
 		assert.Len(t, m.(LeaveRoomEvent).ActorList, 2)
 	}
 	{
-		m := <-a3.Inbox()
+		m := <-a3.Inbox(r.ID())
 		require.IsType(t, m, LeaveRoomEvent{})
 		assert.Len(t, m.(LeaveRoomEvent).ActorList, 2)
 	}
 
 	body = make([]byte, 1024)
 	rand.Read(body)
-	a3.BroadcastToRoom(Payload{0x02, body})
+	a3.BroadcastToRoom(ctx, r.ID(), Payload{0x02, body})
 
 	n = 0
 M:
@@ -123,14 +125,14 @@ M:
 		case <-ctx.Done():
 			t.Fatal(ctx.Err())
 			return
-		case m := <-a1.Inbox():
+		case m := <-a1.Inbox(r.ID()):
 			require.IsType(t, m, ActorMessage{})
 			am := m.(ActorMessage)
 			assert.Equal(t, a3.ActorID(), am.Sender)
 			assert.EqualValues(t, 0x02, am.Code)
 			assert.Equal(t, body, am.Payload)
 			n += 1
-		case m := <-a3.Inbox():
+		case m := <-a3.Inbox(r.ID()):
 			require.IsType(t, m, ActorMessage{})
 			am := m.(ActorMessage)
 			assert.Equal(t, a3.ActorID(), am.Sender)
@@ -145,21 +147,21 @@ M:
 	}
 
 	a4 := NewActor()
-	a4.JoinTo(r)
+	a4.JoinTo(ctx, r)
 	{
-		m := <-a1.Inbox()
+		m := <-a1.Inbox(r.ID())
 		require.IsType(t, m, JoinRoomEvent{})
 		assert.Len(t, m.(JoinRoomEvent).ActorList, 3)
 	}
 	{
-		m := <-a3.Inbox()
+		m := <-a3.Inbox(r.ID())
 		require.IsType(t, m, JoinRoomEvent{})
 		assert.Len(t, m.(JoinRoomEvent).ActorList, 3)
 	}
 
 	body = make([]byte, 1024)
 	rand.Read(body)
-	a4.BroadcastToRoom(Payload{0x03, body})
+	a4.BroadcastToRoom(ctx, r.ID(), Payload{0x03, body})
 
 	n = 0
 N:
@@ -168,21 +170,21 @@ N:
 		case <-ctx.Done():
 			t.Fatal(ctx.Err())
 			return
-		case m := <-a1.Inbox():
+		case m := <-a1.Inbox(r.ID()):
 			require.IsType(t, m, ActorMessage{})
 			am := m.(ActorMessage)
 			assert.Equal(t, a4.ActorID(), am.Sender)
 			assert.EqualValues(t, 0x03, am.Code)
 			assert.Equal(t, body, am.Payload)
 			n += 1
-		case m := <-a3.Inbox():
+		case m := <-a3.Inbox(r.ID()):
 			require.IsType(t, m, ActorMessage{})
 			am := m.(ActorMessage)
 			assert.Equal(t, a4.ActorID(), am.Sender)
 			assert.EqualValues(t, 0x03, am.Code)
 			assert.Equal(t, body, am.Payload)
 			n += 1
-		case m := <-a4.Inbox():
+		case m := <-a4.Inbox(r.ID()):
 			require.IsType(t, m, ActorMessage{})
 			am := m.(ActorMessage)
 			assert.Equal(t, a4.ActorID(), am.Sender)
@@ -196,3 +198,332 @@ N:
 		}
 	}
 }
+
+func TestActor_Teams(t *testing.T) {
+	r := NewRoom(RoomOptions{TeamCount: 2, TeamSize: 2})
+	defer r.Stop()
+
+	ctx := context.Background()
+
+	a1 := NewActor()
+	a2 := NewActor()
+	a3 := NewActor()
+	a4 := NewActor()
+
+	a1.JoinTo(ctx, r)
+	a2.JoinTo(ctx, r)
+	a3.JoinTo(ctx, r)
+	a4.JoinTo(ctx, r)
+
+	var teams map[ActorID]TeamID
+	for i := 0; i < 3; i++ {
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, JoinRoomEvent{})
+		teams = m.(JoinRoomEvent).Teams
+	}
+	assert.Equal(t, TeamID(1), teams[a1.ActorID()])
+	assert.Equal(t, TeamID(2), teams[a2.ActorID()])
+	assert.Equal(t, TeamID(1), teams[a3.ActorID()])
+	assert.Equal(t, TeamID(2), teams[a4.ActorID()])
+
+	<-a2.Inbox(r.ID())
+	<-a2.Inbox(r.ID())
+	<-a3.Inbox(r.ID())
+
+	assert.Equal(t, ErrTeamFull, a1.ChangeTeam(ctx, r.ID(), 2))
+	assert.Equal(t, ErrTeamNotFound, a1.ChangeTeam(ctx, r.ID(), 3))
+	assert.Equal(t, ErrNotRoomMaster, a2.SwapTeams(ctx, r.ID(), a1.ActorID(), a2.ActorID()))
+
+	require.NoError(t, a1.SwapTeams(ctx, r.ID(), a1.ActorID(), a2.ActorID()))
+	{
+		m := <-a3.Inbox(r.ID())
+		require.IsType(t, m, TeamChangedEvent{})
+		teams := m.(TeamChangedEvent).Teams
+		assert.Equal(t, TeamID(2), teams[a1.ActorID()])
+		assert.Equal(t, TeamID(1), teams[a2.ActorID()])
+	}
+	<-a1.Inbox(r.ID())
+	<-a2.Inbox(r.ID())
+	<-a4.Inbox(r.ID())
+
+	a3.BroadcastToTeam(ctx, r.ID(), Payload{0x01, []byte("team")})
+	{
+		m := <-a2.Inbox(r.ID())
+		require.IsType(t, m, ActorMessage{})
+		assert.Equal(t, a3.ActorID(), m.(ActorMessage).Sender)
+	}
+	{
+		m := <-a3.Inbox(r.ID())
+		require.IsType(t, m, ActorMessage{})
+	}
+	select {
+	case m := <-a1.Inbox(r.ID()):
+		t.Fatalf("unexpected message: %v", m)
+	case m := <-a4.Inbox(r.ID()):
+		t.Fatalf("unexpected message: %v", m)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestActor_Request(t *testing.T) {
+	r := NewRoom(RoomOptions{
+		RequestHandler: func(ctx context.Context, req RequestMessage) (Payload, error) {
+			return Payload{Code: req.Code + 1, Body: req.Payload}, nil
+		},
+	})
+	defer r.Stop()
+
+	ctx := context.Background()
+
+	a1 := NewActor()
+	a2 := NewActor()
+	a1.JoinTo(ctx, r)
+	a2.JoinTo(ctx, r)
+	<-a1.Inbox(r.ID())
+
+	require.NoError(t, a1.Request(ctx, r.ID(), a2.ActorID(), 1, Payload{0x01, []byte("req")}, 0))
+	{
+		m := <-a2.Inbox(r.ID())
+		require.IsType(t, m, RequestMessage{})
+		req := m.(RequestMessage)
+		assert.Equal(t, a1.ActorID(), req.Sender)
+		assert.EqualValues(t, 1, req.RequestID)
+
+		assert.NoError(t, a2.Respond(ctx, r.ID(), req.Sender, req.RequestID, Payload{0x02, []byte("res")}))
+		assert.Equal(t, ErrRequestNotPending, a2.Respond(ctx, r.ID(), req.Sender, req.RequestID, Payload{0x02, []byte("res")}))
+	}
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, ResponseMessage{})
+		res := m.(ResponseMessage)
+		assert.NoError(t, res.Err)
+		assert.Equal(t, a2.ActorID(), res.Sender)
+		assert.EqualValues(t, 0x02, res.Code)
+		assert.Equal(t, []byte("res"), res.Payload)
+	}
+
+	require.NoError(t, a1.Request(ctx, r.ID(), "", 2, Payload{0x03, nil}, 0))
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, ResponseMessage{})
+		res := m.(ResponseMessage)
+		assert.NoError(t, res.Err)
+		assert.EqualValues(t, 0x04, res.Code)
+	}
+
+	require.NoError(t, a1.Request(ctx, r.ID(), a2.ActorID(), 3, Payload{0x01, nil}, 10*time.Millisecond))
+	<-a2.Inbox(r.ID())
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, ResponseMessage{})
+		assert.Equal(t, ErrRequestTimeout, m.(ResponseMessage).Err)
+	}
+
+	require.NoError(t, a1.Request(ctx, r.ID(), a2.ActorID(), 4, Payload{0x01, nil}, 0))
+	<-a2.Inbox(r.ID())
+	a2.Leave(ctx, r.ID())
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, ResponseMessage{})
+		assert.Equal(t, ErrRequestTargetLeft, m.(ResponseMessage).Err)
+	}
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, LeaveRoomEvent{})
+	}
+}
+
+func TestActor_RequestHandlerTimeout(t *testing.T) {
+	handled := make(chan error, 1)
+	r := NewRoom(RoomOptions{
+		RequestHandler: func(ctx context.Context, req RequestMessage) (Payload, error) {
+			<-ctx.Done()
+			handled <- ctx.Err()
+			return Payload{}, nil
+		},
+	})
+	defer r.Stop()
+
+	ctx := context.Background()
+
+	a1 := NewActor()
+	a2 := NewActor()
+	a1.JoinTo(ctx, r)
+	a2.JoinTo(ctx, r)
+	<-a1.Inbox(r.ID())
+
+	require.NoError(t, a1.Request(ctx, r.ID(), "", 1, Payload{Code: 0x01}, 50*time.Millisecond))
+	// the room goes on while the handler runs
+	require.NoError(t, a2.BroadcastToRoom(ctx, r.ID(), Payload{Code: 0x02}))
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, ActorMessage{})
+		assert.EqualValues(t, 0x02, m.(ActorMessage).Code)
+	}
+	assert.Equal(t, ErrRequestDuplicated, a1.Request(ctx, r.ID(), "", 1, Payload{Code: 0x01}, 0))
+
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, ResponseMessage{})
+		assert.Equal(t, ErrRequestTimeout, m.(ResponseMessage).Err)
+	}
+	assert.Error(t, <-handled)
+	// the late response of the handler is dropped
+	select {
+	case m := <-a1.Inbox(r.ID()):
+		t.Fatalf("unexpected message: %v", m)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestActor_RequestDuplicated(t *testing.T) {
+	r := NewRoom(RoomOptions{})
+	defer r.Stop()
+
+	ctx := context.Background()
+
+	a1 := NewActor()
+	a2 := NewActor()
+	a1.JoinTo(ctx, r)
+	a2.JoinTo(ctx, r)
+	<-a1.Inbox(r.ID())
+
+	require.NoError(t, a1.Request(ctx, r.ID(), a2.ActorID(), 1, Payload{Code: 0x01}, 0))
+	<-a2.Inbox(r.ID())
+	assert.Equal(t, ErrRequestDuplicated, a1.Request(ctx, r.ID(), a2.ActorID(), 1, Payload{Code: 0x02}, 0))
+
+	require.NoError(t, a2.Respond(ctx, r.ID(), a1.ActorID(), 1, Payload{Code: 0x03}))
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, ResponseMessage{})
+		res := m.(ResponseMessage)
+		assert.NoError(t, res.Err)
+		assert.EqualValues(t, 0x03, res.Code)
+	}
+	select {
+	case m := <-a1.Inbox(r.ID()):
+		t.Fatalf("unexpected message: %v", m)
+	case m := <-a2.Inbox(r.ID()):
+		t.Fatalf("unexpected message: %v", m)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestActor_LeaveWithReason(t *testing.T) {
+	r := NewRoom(RoomOptions{})
+	defer r.Stop()
+
+	ctx := context.Background()
+
+	a1 := NewActor()
+	a2 := NewActor()
+	a3 := NewActor()
+	a1.JoinTo(ctx, r)
+	a2.JoinTo(ctx, r)
+	a3.JoinTo(ctx, r)
+	<-a1.Inbox(r.ID())
+	<-a1.Inbox(r.ID())
+	<-a2.Inbox(r.ID())
+
+	a3.LeaveWithReason(ctx, r.ID(), LeaveReasonTimedOut, "idle timeout")
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, LeaveRoomEvent{})
+		ev := m.(LeaveRoomEvent)
+		assert.Equal(t, a3.ActorID(), ev.RemovedActor)
+		assert.Equal(t, LeaveReasonTimedOut, ev.Reason)
+		assert.Equal(t, "idle timeout", ev.Detail)
+	}
+	<-a2.Inbox(r.ID())
+
+	require.NoError(t, r.Kick(ctx, a2.ActorID(), "cheating"))
+	{
+		m := <-a2.Inbox(r.ID())
+		require.IsType(t, m, LeaveRoomEvent{})
+		ev := m.(LeaveRoomEvent)
+		assert.Equal(t, a2.ActorID(), ev.RemovedActor)
+		assert.Equal(t, LeaveReasonKicked, ev.Reason)
+
+		_, ok := <-a2.Inbox(r.ID())
+		assert.False(t, ok)
+	}
+	{
+		m := <-a1.Inbox(r.ID())
+		require.IsType(t, m, LeaveRoomEvent{})
+		ev := m.(LeaveRoomEvent)
+		assert.Equal(t, a2.ActorID(), ev.RemovedActor)
+		assert.Equal(t, LeaveReasonKicked, ev.Reason)
+		assert.Equal(t, "cheating", ev.Detail)
+		assert.Equal(t, []ActorID{a1.ActorID()}, ev.ActorList)
+	}
+}
+
+func TestActor_JoinTo(t *testing.T) {
+	r := NewRoom(RoomOptions{MaxActors: 2})
+	defer r.Stop()
+
+	ctx := context.Background()
+	a1 := NewActor()
+	a2 := NewActor()
+	a3 := NewActor()
+
+	require.NoError(t, a1.JoinTo(ctx, r))
+	assert.Equal(t, ErrAlreadyMember, a1.JoinTo(ctx, r))
+	require.NoError(t, a2.JoinTo(ctx, r))
+	assert.Equal(t, ErrRoomFull, a3.JoinTo(ctx, r))
+	assert.False(t, a3.InRoom(r.ID()))
+
+	_, err := r.NewEntry(ctx, a1.ActorID())
+	assert.Equal(t, ErrAlreadyMember, err)
+
+	require.NoError(t, r.Close(ctx, LeaveReasonRoomClosed, ""))
+	assert.Equal(t, ErrRoomClosed, a1.BroadcastToRoom(ctx, r.ID(), Payload{0x01, nil}))
+	assert.Equal(t, ErrRoomClosed, a3.JoinTo(ctx, r))
+	assert.Equal(t, ErrRoomClosed, r.Kick(ctx, a2.ActorID(), ""))
+	assert.Equal(t, ErrRoomClosed, r.Close(ctx, LeaveReasonRoomClosed, ""))
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	r2 := NewRoom(RoomOptions{})
+	defer r2.Stop()
+	assert.Equal(t, context.Canceled, r2.Close(cancelled, LeaveReasonRoomClosed, ""))
+}
+
+func TestActor_MultipleRooms(t *testing.T) {
+	r1 := NewRoom(RoomOptions{})
+	defer r1.Stop()
+	r2 := NewRoom(RoomOptions{})
+	defer r2.Stop()
+
+	ctx := context.Background()
+	a1 := NewActor()
+	a2 := NewActor()
+
+	require.NoError(t, a1.JoinTo(ctx, r1))
+	require.NoError(t, a1.JoinTo(ctx, r2))
+	assert.Equal(t, ErrAlreadyMember, a1.JoinTo(ctx, r2))
+	assert.ElementsMatch(t, []quark.RoomID{r1.ID(), r2.ID()}, a1.Rooms())
+
+	require.NoError(t, a2.JoinTo(ctx, r2))
+	<-a1.Inbox(r2.ID())
+
+	require.NoError(t, a2.BroadcastToRoom(ctx, r2.ID(), Payload{0x01, []byte("r2")}))
+	{
+		m := <-a1.Inbox(r2.ID())
+		require.IsType(t, m, ActorMessage{})
+		assert.Equal(t, []byte("r2"), m.(ActorMessage).Payload)
+	}
+	select {
+	case m := <-a1.Inbox(r1.ID()):
+		t.Fatalf("unexpected message: %v", m)
+	default:
+	}
+	assert.Equal(t, ErrNotMember, a2.BroadcastToRoom(ctx, r1.ID(), Payload{0x01, nil}))
+
+	require.NoError(t, a1.Leave(ctx, r2.ID()))
+	assert.False(t, a1.InRoom(r2.ID()))
+	assert.True(t, a1.InRoom(r1.ID()))
+
+	a1.LeaveAll(ctx, LeaveReasonDisconnected, "")
+	assert.Empty(t, a1.Rooms())
+}
@@ -0,0 +1,17 @@
+package gameserver
+
+import (
+	"context"
+
+	"quark"
+)
+
+// BotFunc runs a bot actor which has joined the room.
+// It must keep reading the inbox of the room and return once ctx is done.
+type BotFunc func(ctx context.Context, roomID quark.RoomID, a *Actor)
+
+type botHandle struct {
+	roomID quark.RoomID
+	cancel context.CancelFunc
+	done   <-chan struct{}
+}
@@ -10,22 +10,91 @@ const (
 	_ RoomEventType = iota
 	OnJoinRoom
 	OnLeaveRoom
+	OnTeamChanged
+	OnActorPropertiesChanged
+	OnRoomClosed
+	OnMessageRejected
+	OnMessageAccepted
 )
 
 type JoinRoomEvent struct {
 	ActorList []ActorID
 	NewActor  ActorID
+	Teams     map[ActorID]TeamID
+	BotList   []ActorID
 }
 
 func (e *JoinRoomEvent) EventType() RoomEventType {
 	return OnJoinRoom
 }
 
+type LeaveReason int
+
+const (
+	LeaveReasonVoluntary LeaveReason = iota
+	LeaveReasonDisconnected
+	LeaveReasonTimedOut
+	LeaveReasonKicked
+	LeaveReasonRoomClosed
+	LeaveReasonServerShutdown
+)
+
 type LeaveRoomEvent struct {
 	ActorList    []ActorID
 	RemovedActor ActorID
+	Teams        map[ActorID]TeamID
+	BotList      []ActorID
+	Reason       LeaveReason
+	Detail       string
 }
 
 func (e *LeaveRoomEvent) EventType() RoomEventType {
 	return OnLeaveRoom
 }
+
+type TeamChangedEvent struct {
+	Teams map[ActorID]TeamID
+}
+
+func (e *TeamChangedEvent) EventType() RoomEventType {
+	return OnTeamChanged
+}
+
+type ActorPropertiesChangedEvent struct {
+	Actor      ActorID
+	Properties map[string]string
+}
+
+func (e *ActorPropertiesChangedEvent) EventType() RoomEventType {
+	return OnActorPropertiesChanged
+}
+
+// RoomClosedEvent is the last message of the inbox when the room is closed.
+// Reason is either LeaveReasonRoomClosed or LeaveReasonServerShutdown.
+type RoomClosedEvent struct {
+	Reason LeaveReason
+	Detail string
+}
+
+func (e *RoomClosedEvent) EventType() RoomEventType {
+	return OnRoomClosed
+}
+
+// MessageRejectedEvent is sent to the sender of a message which an interceptor rejected.
+type MessageRejectedEvent struct {
+	Message ActorMessage
+	Err     error
+}
+
+func (e *MessageRejectedEvent) EventType() RoomEventType {
+	return OnMessageRejected
+}
+
+// MessageAcceptedEvent is sent to the sender of a message with Ack once the room delivered it.
+type MessageAcceptedEvent struct {
+	Message ActorMessage
+}
+
+func (e *MessageAcceptedEvent) EventType() RoomEventType {
+	return OnMessageAccepted
+}
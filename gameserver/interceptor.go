@@ -0,0 +1,61 @@
+package gameserver
+
+import (
+	"errors"
+
+	"quark"
+)
+
+// ErrForbidden can be returned by interceptors for messages the sender is not allowed to send.
+var ErrForbidden = errors.New("forbidden")
+
+// MessageInfo describes the sender of an ActorMessage passing through the interceptors of a room.
+type MessageInfo struct {
+	Room   quark.RoomID
+	Sender ActorID
+	// User is the identity of the user behind the sender; empty if unknown
+	User string
+	Bot  bool
+}
+
+// MessageHandler passes the message on to the next interceptor, or fans it out after the last one.
+type MessageHandler func(m ActorMessage) error
+
+// MessageInterceptor sees every ActorMessage of a room before it is fanned out.
+// It passes the message, possibly rewritten, to next; it drops the message by returning nil
+// without calling next, and rejects it by returning an error which is sent back to the sender
+// as MessageRejectedEvent. Only the Code and Payload of a rewritten message are delivered;
+// the sender and the audience stay those of the original message.
+// Interceptors run on the room and must call next before returning.
+type MessageInterceptor func(info MessageInfo, m ActorMessage, next MessageHandler) error
+
+// intercept passes m through the interceptors and reports whether it was delivered
+func (st *roomState) intercept(m ActorMessage) (bool, error) {
+	info := MessageInfo{
+		Room:   st.r.id,
+		Sender: m.Sender,
+		User:   st.users[m.Sender],
+		Bot:    st.bots[m.Sender],
+	}
+
+	delivered := false
+	var call func(i int, rewritten ActorMessage) error
+	call = func(i int, rewritten ActorMessage) error {
+		if i == len(st.opts.Interceptors) {
+			// the sender and the audience can not be rewritten
+			out := m
+			out.Code = rewritten.Code
+			out.Payload = rewritten.Payload
+			st.deliver(out)
+			delivered = true
+			return nil
+		}
+		return st.opts.Interceptors[i](info, rewritten, func(rewritten ActorMessage) error {
+			return call(i+1, rewritten)
+		})
+	}
+	if err := call(0, m); err != nil {
+		return false, err
+	}
+	return delivered, nil
+}
@@ -0,0 +1,214 @@
+package gameserver
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// inboxSize is the capacity of the subscription channel. It is small so
+	// that messages wait in the queue, where keyed messages are coalesced.
+	inboxSize = 1
+	// maxQueuedMessages is the number of messages a subscriber can fall behind
+	// before it is removed from the room
+	maxQueuedMessages = 1024
+	// drainTimeout bounds the delivery of queued messages after the outbox is closed
+	drainTimeout = 5 * time.Second
+)
+
+// outbox delivers messages to a subscriber without blocking the room.
+// Messages which do not fit in the channel are queued and pumped by a goroutine
+// which only exists while the queue is not empty.
+// A queued ActorMessage with a CoalesceKey is replaced by a newer one with the same sender and key,
+// so only the message already in the channel can be outdated.
+// When the queue overflows, the outbox takes no more messages and calls onOverflow.
+type outbox struct {
+	c          chan Message
+	onOverflow func()
+
+	mu       sync.Mutex
+	overflow []Message
+	// the number of messages popped from overflow, to locate keyed messages
+	popped     int
+	keyed      map[coalesceKey]int
+	pumping    bool
+	overflowed bool
+	closed     bool
+	// the last message of a closed outbox, queued at the end of overflow
+	final Message
+	done  chan struct{}
+
+	// set when the head of overflow is replaced while the pump is sending it
+	headReplaced bool
+	replaced     chan struct{}
+}
+
+type coalesceKey struct {
+	sender ActorID
+	key    string
+}
+
+func keyOf(m Message) (coalesceKey, bool) {
+	if m, ok := m.(ActorMessage); ok && len(m.CoalesceKey) != 0 {
+		return coalesceKey{sender: m.Sender, key: m.CoalesceKey}, true
+	}
+	return coalesceKey{}, false
+}
+
+func newOutbox(onOverflow func()) *outbox {
+	return &outbox{
+		c:          make(chan Message, inboxSize),
+		onOverflow: onOverflow,
+		done:       make(chan struct{}),
+		replaced:   make(chan struct{}, 1),
+	}
+}
+
+func (o *outbox) push(m Message) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed || o.overflowed {
+		return
+	}
+	if !o.pumping {
+		select {
+		case o.c <- m:
+			return
+		default:
+		}
+		o.pumping = true
+		go o.pump()
+	}
+
+	if k, ok := keyOf(m); ok {
+		if i, ok := o.keyed[k]; ok {
+			o.overflow[i-o.popped] = m
+			if i == o.popped {
+				o.headReplaced = true
+				select {
+				case o.replaced <- struct{}{}:
+				default:
+				}
+			}
+			return
+		}
+	}
+	if maxQueuedMessages <= len(o.overflow) {
+		o.overflowed = true
+		if o.onOverflow != nil {
+			o.onOverflow()
+		}
+		return
+	}
+	if k, ok := keyOf(m); ok {
+		if o.keyed == nil {
+			o.keyed = map[coalesceKey]int{}
+		}
+		o.keyed[k] = o.popped + len(o.overflow)
+	}
+	o.overflow = append(o.overflow, m)
+}
+
+func (o *outbox) pump() {
+	done := o.done
+	var deadline <-chan time.Time
+	for {
+		o.mu.Lock()
+		if len(o.overflow) == 0 {
+			o.pumping = false
+			o.overflow = nil
+			o.keyed = nil
+			if o.closed {
+				close(o.c)
+			}
+			o.mu.Unlock()
+			return
+		}
+		// the head stays in overflow until it is sent so that it can still be replaced
+		m := o.overflow[0]
+		o.headReplaced = false
+		o.mu.Unlock()
+
+		select {
+		case o.c <- m:
+			o.mu.Lock()
+			if !o.headReplaced {
+				o.pop()
+			}
+			o.mu.Unlock()
+		case <-o.replaced:
+		case <-done:
+			done = nil
+			timer := time.NewTimer(drainTimeout)
+			defer timer.Stop()
+			deadline = timer.C
+		case <-deadline:
+			o.abandon()
+			return
+		}
+	}
+}
+
+// abandon drops the queued messages of a closed outbox whose subscriber does
+// not read, and closes the channel after the final message
+func (o *outbox) abandon() {
+	o.mu.Lock()
+	final := o.final
+	o.pumping = false
+	o.overflow = nil
+	o.keyed = nil
+	o.mu.Unlock()
+
+	if final != nil {
+		// make room for the final message; only the subscriber takes messages out
+		select {
+		case <-o.c:
+		default:
+		}
+		o.c <- final
+	}
+	close(o.c)
+}
+
+func (o *outbox) pop() {
+	m := o.overflow[0]
+	o.overflow[0] = nil
+	o.overflow = o.overflow[1:]
+	if k, ok := keyOf(m); ok {
+		delete(o.keyed, k)
+	}
+	o.popped += 1
+}
+
+// close closes the channel after the queued messages and final, if not nil.
+// If the subscriber does not read them within drainTimeout, the queued
+// messages are dropped but final is still the last message of the channel.
+func (o *outbox) close(final Message) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		return
+	}
+	o.closed = true
+	o.final = final
+	close(o.done)
+	if final == nil {
+		if !o.pumping {
+			close(o.c)
+		}
+		return
+	}
+	if !o.pumping {
+		select {
+		case o.c <- final:
+			close(o.c)
+			return
+		default:
+		}
+		o.pumping = true
+		go o.pump()
+	}
+	o.overflow = append(o.overflow, final)
+}
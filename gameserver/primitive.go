@@ -1,6 +1,10 @@
 package gameserver
 
-import "github.com/google/uuid"
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
 
 type ActorID string
 
@@ -14,26 +18,112 @@ func (a ActorID) String() string {
 
 type Message interface{}
 
+// RTTPropertyKey is the actor property holding the measured round trip time in milliseconds.
+const RTTPropertyKey = "rtt"
+
 type ActorMessage struct {
 	Sender  ActorID
 	Code    uint32
 	Payload []byte
+
+	// TeamOnly delivers the message only to the sender's team.
+	TeamOnly bool
+	// CoalesceKey marks the message as latest-wins: a newer message from the same sender
+	// with the same key replaces it while it is still queued for a subscriber. A subscriber
+	// which is behind receives at most one outdated message, the one already in its channel.
+	CoalesceKey string
+
+	// CorrelationID is the ID of the sender's command, echoed on MessageAcceptedEvent and MessageRejectedEvent
+	CorrelationID uint64
+	// Ack sends MessageAcceptedEvent to the sender once the room delivered the message
+	Ack bool
 }
 
 type RoomEntry struct {
-	id ActorID
-	r  *Room
-	s  chan Message
+	id      ActorID
+	r       *Room
+	s       chan Message
+	removed <-chan struct{}
 }
 
 func (e *RoomEntry) Subscription() <-chan Message {
 	return e.s
 }
 
-func (e *RoomEntry) Send(m ActorMessage) {
-	e.r.messages <- m
+// check returns an error if the entry can no longer be used
+func (e *RoomEntry) check(ctx context.Context) error {
+	if e.r.isClosed() {
+		return ErrRoomClosed
+	}
+	select {
+	case <-e.removed:
+		return ErrNotMember
+	default:
+		return ctx.Err()
+	}
+}
+
+func (e *RoomEntry) Send(ctx context.Context, m ActorMessage) error {
+	if err := e.check(ctx); err != nil {
+		return err
+	}
+	return e.r.post(m)
+}
+
+// Request sends the request. A request ID which is still pending is rejected
+// with ErrRequestDuplicated; any other failure is the response of the request.
+func (e *RoomEntry) Request(ctx context.Context, req RequestMessage) error {
+	if err := e.check(ctx); err != nil {
+		return err
+	}
+	out := make(chan error, 1)
+	if err := e.r.post(roomRequestCmd{req: req, out: out}); err != nil {
+		return err
+	}
+	return e.r.await(ctx, out)
+}
+
+func (e *RoomEntry) Respond(ctx context.Context, res ResponseMessage) error {
+	if err := e.check(ctx); err != nil {
+		return err
+	}
+	out := make(chan error, 1)
+	if err := e.r.post(roomResponseCmd{res: res, out: out}); err != nil {
+		return err
+	}
+	return e.r.await(ctx, out)
+}
+
+func (e *RoomEntry) SetProperties(ctx context.Context, props map[string]string) error {
+	if err := e.check(ctx); err != nil {
+		return err
+	}
+	return e.r.post(roomPropsCmd{actorID: e.id, properties: props})
+}
+
+func (e *RoomEntry) Leave(ctx context.Context, reason LeaveReason, detail string) error {
+	if err := e.check(ctx); err != nil {
+		return err
+	}
+	return e.r.removeActor(ctx, roomLeaveCmd{actorID: e.id, reason: reason, detail: detail})
+}
+
+func (e *RoomEntry) ChangeTeam(ctx context.Context, team TeamID) error {
+	return e.teamCmd(ctx, roomTeamCmd{actorID: e.id, team: team})
+}
+
+func (e *RoomEntry) SwapTeams(ctx context.Context, a, b ActorID) error {
+	return e.teamCmd(ctx, roomTeamCmd{actorID: e.id, target: a, swapWith: b})
 }
 
-func (e *RoomEntry) Leave() {
-	e.r.leave <- e.id
+func (e *RoomEntry) teamCmd(ctx context.Context, cmd roomTeamCmd) error {
+	if err := e.check(ctx); err != nil {
+		return err
+	}
+	out := make(chan error, 1)
+	cmd.out = out
+	if err := e.r.post(cmd); err != nil {
+		return err
+	}
+	return e.r.await(ctx, out)
 }
@@ -0,0 +1,86 @@
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrRequestTimeout        = errors.New("request timed out")
+	ErrRequestTargetNotFound = errors.New("request target not found")
+	ErrRequestTargetLeft     = errors.New("request target left the room")
+	ErrRequestDuplicated     = errors.New("request ID is already in use")
+	ErrRequestNotHandled     = errors.New("room has no request handler")
+	ErrRequestNotPending     = errors.New("request is not pending")
+)
+
+const DefaultRequestTimeout = 5 * time.Second
+
+// RequestHandler answers requests which are sent to the room itself rather than to an actor.
+// It runs on its own goroutine so that it does not hold up the room. ctx is done when the
+// request times out, the requester leaves or the room closes; the response is dropped then.
+type RequestHandler func(ctx context.Context, req RequestMessage) (Payload, error)
+
+type RequestMessage struct {
+	Sender ActorID
+	// Target is empty if the request is sent to the room itself
+	Target    ActorID
+	RequestID uint64
+	Code      uint32
+	Payload   []byte
+	Timeout   time.Duration
+	// CorrelationID is the ID of the sender's command, echoed on the ResponseMessage
+	CorrelationID uint64
+}
+
+// ResponseMessage is delivered exactly once to the requester for each request.
+// Err is set if the request failed, e.g. timed out or the target left the room.
+type ResponseMessage struct {
+	Sender    ActorID
+	Requester ActorID
+	RequestID uint64
+	Code      uint32
+	Payload   []byte
+	Err       error
+	// CorrelationID is the one of the request, set by the room
+	CorrelationID uint64
+}
+
+type requestKey struct {
+	requester ActorID
+	requestID uint64
+}
+
+type pendingRequest struct {
+	target        ActorID
+	timer         *time.Timer
+	correlationID uint64
+	// ctx and cancel are set for a request to the RequestHandler
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (p pendingRequest) stop() {
+	p.timer.Stop()
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+type roomRequestCmd struct {
+	req RequestMessage
+	out chan<- error
+}
+
+type roomResponseCmd struct {
+	res ResponseMessage
+	out chan<- error
+}
+
+// roomHandledCmd is the response of the RequestHandler to the request of ctx
+type roomHandledCmd struct {
+	key requestKey
+	ctx context.Context
+	res ResponseMessage
+}
@@ -1,95 +1,725 @@
 package gameserver
 
-type subscription chan<- Message
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
 
+	"quark"
+)
+
+var (
+	ErrRoomClosed    = errors.New("room is closed")
+	ErrRoomFull      = errors.New("room is full")
+	ErrNotMember     = errors.New("actor is not a member of the room")
+	ErrAlreadyMember = errors.New("actor is already a member of a room")
+	ErrNotRoomMaster = errors.New("actor is not the room master")
+)
+
+const (
+	// rooms with at least this many members fan out messages in parallel
+	parallelFanoutThreshold = 512
+	fanoutChunkSize         = 256
+)
+
+const DefaultReservationTTL = 30 * time.Second
+
+type RoomOptions struct {
+	// MaxActors limits the number of actors in the room; 0 means no limit
+	MaxActors uint
+
+	TeamCount uint
+	TeamSize  uint
+	// UserTeams puts the actors of the users into their teams when they join
+	UserTeams map[string]TeamID
+
+	// RequestHandler handles requests targeted to the room itself
+	RequestHandler RequestHandler
+	// RequestTimeout is used for requests without their own timeout
+	RequestTimeout time.Duration
+
+	// Interceptors see every ActorMessage before it is fanned out, in order
+	Interceptors []MessageInterceptor
+
+	// Scheduler runs the room; DefaultScheduler is used if nil
+	Scheduler *Scheduler
+
+	// ReservedUserIDs hold seats under MaxActors for the users; other actors can not take them
+	ReservedUserIDs []string
+	// ReservationTTL releases the seats of users who have not joined in time;
+	// DefaultReservationTTL is used if 0
+	ReservationTTL time.Duration
+}
+
+func (o RoomOptions) HasTeams() bool {
+	return 0 < o.TeamCount
+}
+
+// Room handles the commands in its mailbox one by one on its scheduler.
 type Room struct {
-	join     chan<- roomJoinCmd
-	leave    chan<- ActorID
-	messages chan<- ActorMessage
+	id    quark.RoomID
+	sched *Scheduler
+
+	mu        sync.Mutex
+	mailbox   []interface{}
+	scheduled bool
+	stopped   bool
+
+	closed chan struct{}
 
-	done chan<- interface{}
+	// only touched while handling commands
+	state *roomState
+}
+
+type roomCloseCmd struct {
+	reason LeaveReason
+	detail string
 }
 
 type roomJoinCmd struct {
 	actorID ActorID
-	out     chan<- (chan Message)
+	bot     bool
+	user    string
+	out     chan<- roomJoinResult
+}
+
+type roomJoinResult struct {
+	s       chan Message
+	removed chan struct{}
+	err     error
+}
+
+type roomLeaveCmd struct {
+	actorID ActorID
+	reason  LeaveReason
+	detail  string
+	// the outbox which overflowed, if the actor is removed for it
+	overflowed *outbox
+	out        chan<- error
+}
+
+type roomPropsCmd struct {
+	actorID    ActorID
+	properties map[string]string
+}
+
+type roomTeamCmd struct {
+	actorID ActorID
+	// change the team of actorID to team if swapWith is empty,
+	// otherwise swap the teams of target and swapWith
+	team     TeamID
+	target   ActorID
+	swapWith ActorID
+	out      chan<- error
 }
 
-func NewRoom() *Room {
-	messages := make(chan ActorMessage, 16)
+type roomReserveCmd struct {
+	userIDs   []string
+	expiresAt time.Time
+	out       chan<- error
+}
 
-	join := make(chan roomJoinCmd)
-	leave := make(chan ActorID)
+type roomExpiredCmd struct {
+	key requestKey
+}
 
-	done := make(chan interface{})
+func NewRoom(opts RoomOptions) *Room {
+	return newRoom(quark.NewRoomID(), opts, nil)
+}
 
-	go func() {
-		defer close(join)
-		defer close(leave)
-		defer close(messages)
-		defer close(done)
+// newRoom creates a room which calls onClose once it is closed.
+func newRoom(id quark.RoomID, opts RoomOptions, onClose func()) *Room {
+	if opts.RequestTimeout <= 0 {
+		opts.RequestTimeout = DefaultRequestTimeout
+	}
+	if opts.ReservationTTL <= 0 {
+		opts.ReservationTTL = DefaultReservationTTL
+	}
+	sched := opts.Scheduler
+	if sched == nil {
+		sched = DefaultScheduler()
+	}
 
-		subscribers := map[ActorID]subscription{}
+	r := &Room{
+		id:     id,
+		sched:  sched,
+		closed: make(chan struct{}),
+	}
+	r.state = &roomState{
+		r:           r,
+		opts:        opts,
+		onClose:     onClose,
+		subscribers: map[ActorID]*outbox{},
+		removed:     map[ActorID]chan struct{}{},
+		bots:        map[ActorID]bool{},
+		users:       map[ActorID]string{},
+		properties:  map[ActorID]map[string]string{},
+		teams:       newTeamSet(opts),
+		pending:     map[requestKey]pendingRequest{},
+		reserved:    map[string]time.Time{},
+	}
+	expiresAt := time.Now().Add(opts.ReservationTTL)
+	for _, u := range opts.ReservedUserIDs {
+		if len(u) != 0 {
+			r.state.reserved[u] = expiresAt
+		}
+	}
+	return r
+}
 
-		currentActors := func() []ActorID {
-			s := make([]ActorID, 0, len(subscribers))
-			for id := range subscribers {
-				s = append(s, id)
+func (r *Room) ID() quark.RoomID {
+	return r.id
+}
+
+// post puts cmd into the mailbox and schedules the room if it is idle.
+func (r *Room) post(cmd interface{}) error {
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		return ErrRoomClosed
+	}
+	r.mailbox = append(r.mailbox, cmd)
+	schedule := !r.scheduled
+	r.scheduled = true
+	r.mu.Unlock()
+
+	if schedule {
+		r.sched.schedule(r)
+	}
+	return nil
+}
+
+// run handles up to limit commands and reports whether the mailbox still has some.
+func (r *Room) run(limit int) bool {
+	for i := 0; i < limit; i++ {
+		r.mu.Lock()
+		if r.stopped {
+			r.mu.Unlock()
+			return false
+		}
+		if len(r.mailbox) == 0 {
+			r.scheduled = false
+			r.mu.Unlock()
+			return false
+		}
+		cmd := r.mailbox[0]
+		r.mailbox[0] = nil
+		r.mailbox = r.mailbox[1:]
+		r.mu.Unlock()
+
+		r.state.handle(cmd)
+	}
+	return true
+}
+
+// await waits for the reply of a posted command.
+func (r *Room) await(ctx context.Context, out <-chan error) error {
+	select {
+	case err := <-out:
+		return err
+	case <-r.closed:
+		return ErrRoomClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Room) NewEntry(ctx context.Context, actorID ActorID) (*RoomEntry, error) {
+	return r.newEntry(ctx, roomJoinCmd{actorID: actorID})
+}
+
+func (r *Room) newEntry(ctx context.Context, cmd roomJoinCmd) (*RoomEntry, error) {
+	out := make(chan roomJoinResult, 1)
+	cmd.out = out
+	if err := r.post(cmd); err != nil {
+		return nil, err
+	}
+
+	var res roomJoinResult
+	select {
+	case res = <-out:
+	case <-r.closed:
+		return nil, ErrRoomClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+	return &RoomEntry{id: cmd.actorID, r: r, s: res.s, removed: res.removed}, nil
+}
+
+// Kick removes the actor from the room.
+func (r *Room) Kick(ctx context.Context, actorID ActorID, detail string) error {
+	return r.removeActor(ctx, roomLeaveCmd{actorID: actorID, reason: LeaveReasonKicked, detail: detail})
+}
+
+func (r *Room) removeActor(ctx context.Context, cmd roomLeaveCmd) error {
+	out := make(chan error, 1)
+	cmd.out = out
+	if err := r.post(cmd); err != nil {
+		return err
+	}
+	return r.await(ctx, out)
+}
+
+// Reserve holds seats for the users until they join or the seats expire after ttl.
+// The seats are reserved under MaxActors; ErrRoomFull is returned if not enough are free.
+func (r *Room) Reserve(ctx context.Context, userIDs []string, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+	out := make(chan error, 1)
+	if err := r.post(roomReserveCmd{userIDs: userIDs, expiresAt: time.Now().Add(ttl), out: out}); err != nil {
+		return err
+	}
+	return r.await(ctx, out)
+}
+
+// Close sends RoomClosedEvent to every member, closes their inboxes and stops the room.
+func (r *Room) Close(ctx context.Context, reason LeaveReason, detail string) error {
+	if err := r.post(roomCloseCmd{reason: reason, detail: detail}); err != nil {
+		return err
+	}
+	select {
+	case <-r.closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Done returns a channel which is closed when the room is closed.
+func (r *Room) Done() <-chan struct{} {
+	return r.closed
+}
+
+func (r *Room) isClosed() bool {
+	select {
+	case <-r.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *Room) Stop() {
+	r.Close(context.Background(), LeaveReasonRoomClosed, "")
+}
+
+type roomState struct {
+	r       *Room
+	opts    RoomOptions
+	onClose func()
+
+	subscribers map[ActorID]*outbox
+	// closed when the actor is removed from the room
+	removed    map[ActorID]chan struct{}
+	bots       map[ActorID]bool
+	users      map[ActorID]string
+	properties map[ActorID]map[string]string
+	teams      *teamSet
+
+	// actors in the order they joined; the first one is the room master
+	members []ActorID
+
+	pending map[requestKey]pendingRequest
+
+	// the expiry of the seats reserved for users
+	reserved map[string]time.Time
+}
+
+func (st *roomState) handle(cmd interface{}) {
+	switch cmd := cmd.(type) {
+	case roomCloseCmd:
+		st.close(cmd)
+	case roomJoinCmd:
+		st.join(cmd)
+	case roomLeaveCmd:
+		st.leave(cmd)
+	case roomTeamCmd:
+		st.changeTeam(cmd)
+	case roomRequestCmd:
+		st.request(cmd)
+	case roomResponseCmd:
+		st.respond(cmd)
+	case roomExpiredCmd:
+		if p, ok := st.pending[cmd.key]; ok {
+			p.stop()
+			delete(st.pending, cmd.key)
+			st.fail(cmd.key, p, ErrRequestTimeout)
+		}
+	case roomHandledCmd:
+		if p, ok := st.pending[cmd.key]; ok && p.ctx == cmd.ctx {
+			// the deadline may pass before the timer is handled
+			expired := cmd.ctx.Err() != nil
+			p.stop()
+			delete(st.pending, cmd.key)
+			if expired {
+				st.fail(cmd.key, p, ErrRequestTimeout)
+			} else {
+				st.reply(cmd.res)
 			}
-			return s
 		}
+	case roomPropsCmd:
+		st.setProperties(cmd)
+	case roomReserveCmd:
+		st.reserve(cmd)
+	case ActorMessage:
+		st.message(cmd)
+	}
+}
+
+func (st *roomState) close(cmd roomCloseCmd) {
+	r := st.r
+	r.mu.Lock()
+	r.stopped = true
+	r.mailbox = nil
+	r.mu.Unlock()
+
+	for _, p := range st.pending {
+		p.stop()
+	}
+	ev := RoomClosedEvent{Reason: cmd.reason, Detail: cmd.detail}
+	for _, s := range st.subscribers {
+		s.close(ev)
+	}
+	close(r.closed)
+
+	if st.onClose != nil {
+		st.onClose()
+	}
+}
+
+func (st *roomState) join(cmd roomJoinCmd) {
+	if _, ok := st.subscribers[cmd.actorID]; ok {
+		cmd.out <- roomJoinResult{err: ErrAlreadyMember}
+		return
+	}
+	if !st.takeSeat(cmd.user, time.Now()) {
+		cmd.out <- roomJoinResult{err: ErrRoomFull}
+		return
+	}
+	actorID := cmd.actorID
+	var s *outbox
+	s = newOutbox(func() {
+		st.r.post(roomLeaveCmd{
+			actorID:    actorID,
+			reason:     LeaveReasonDisconnected,
+			detail:     "too many messages queued",
+			overflowed: s,
+			out:        make(chan error, 1),
+		})
+	})
+	removed := make(chan struct{})
+	st.subscribers[cmd.actorID] = s
+	st.removed[cmd.actorID] = removed
+	st.members = append(st.members, cmd.actorID)
+	if cmd.bot {
+		st.bots[cmd.actorID] = true
+	}
+	if len(cmd.user) != 0 {
+		st.users[cmd.actorID] = cmd.user
+	}
+	st.teams.assign(cmd.actorID, cmd.user)
+	cmd.out <- roomJoinResult{s: s.c, removed: removed}
+
+	ev := JoinRoomEvent{
+		ActorList: st.currentActors(),
+		NewActor:  cmd.actorID,
+		Teams:     st.teams.snapshot(),
+		BotList:   st.currentBots(),
+	}
+	st.broadcast(ev, func(id ActorID) bool {
+		return id != cmd.actorID
+	})
+}
+
+// takeSeat reports whether the user can join, using up the seat reserved for the user
+func (st *roomState) takeSeat(user string, now time.Time) bool {
+	st.expireReservations(now)
+	if st.opts.MaxActors == 0 {
+		delete(st.reserved, user)
+		return true
+	}
+
+	taken := uint(len(st.subscribers))
+	_, ok := st.reserved[user]
+	if !ok {
+		taken += uint(len(st.reserved))
+	}
+	if st.opts.MaxActors <= taken {
+		return false
+	}
+	delete(st.reserved, user)
+	return true
+}
+
+func (st *roomState) reserve(cmd roomReserveCmd) {
+	st.expireReservations(time.Now())
+
+	added := 0
+	for _, u := range cmd.userIDs {
+		if _, ok := st.reserved[u]; len(u) != 0 && !ok {
+			added++
+		}
+	}
+	if 0 < st.opts.MaxActors && st.opts.MaxActors < uint(len(st.subscribers)+len(st.reserved)+added) {
+		cmd.out <- ErrRoomFull
+		return
+	}
+	for _, u := range cmd.userIDs {
+		if len(u) != 0 {
+			st.reserved[u] = cmd.expiresAt
+		}
+	}
+	cmd.out <- nil
+}
+
+func (st *roomState) expireReservations(now time.Time) {
+	for u, expiresAt := range st.reserved {
+		if !now.Before(expiresAt) {
+			delete(st.reserved, u)
+		}
+	}
+}
 
-		for {
-			select {
-			case <-done:
-				return
-			case cmd := <-join:
-				s := make(chan Message, 128)
-				subscribers[cmd.actorID] = s
-				cmd.out <- s
-
-				ev := JoinRoomEvent{
-					ActorList: currentActors(),
-					NewActor:  cmd.actorID,
-				}
-				for id, other := range subscribers {
-					if id != cmd.actorID {
-						other <- ev
-					}
-				}
-			case id := <-leave:
-				if s, ok := subscribers[id]; ok {
-					delete(subscribers, id)
-					close(s)
-
-					ev := LeaveRoomEvent{
-						ActorList:    currentActors(),
-						RemovedActor: id,
-					}
-					for _, other := range subscribers {
-						other <- ev
-					}
-				}
-			case m := <-messages:
-				for _, s := range subscribers {
-					s <- m
-				}
+func (st *roomState) leave(cmd roomLeaveCmd) {
+	id := cmd.actorID
+	s, ok := st.subscribers[id]
+	if !ok || (cmd.overflowed != nil && cmd.overflowed != s) {
+		cmd.out <- ErrNotMember
+		return
+	}
+	close(st.removed[id])
+	delete(st.removed, id)
+	delete(st.subscribers, id)
+	delete(st.bots, id)
+	delete(st.users, id)
+	delete(st.properties, id)
+	st.teams.remove(id)
+	for i, m := range st.members {
+		if m == id {
+			st.members = append(st.members[:i], st.members[i+1:]...)
+			break
+		}
+	}
+
+	for key, p := range st.pending {
+		if key.requester == id {
+			p.stop()
+			delete(st.pending, key)
+		} else if p.target == id {
+			p.stop()
+			delete(st.pending, key)
+			st.fail(key, p, ErrRequestTargetLeft)
+		}
+	}
+
+	ev := LeaveRoomEvent{
+		ActorList:    st.currentActors(),
+		RemovedActor: id,
+		Teams:        st.teams.snapshot(),
+		BotList:      st.currentBots(),
+		Reason:       cmd.reason,
+		Detail:       cmd.detail,
+	}
+	if cmd.reason == LeaveReasonKicked || cmd.overflowed != nil {
+		// let the removed actor know why
+		s.close(ev)
+	} else {
+		s.close(nil)
+	}
+	cmd.out <- nil
+	st.broadcast(ev, nil)
+}
+
+func (st *roomState) changeTeam(cmd roomTeamCmd) {
+	var err error
+	if len(cmd.swapWith) == 0 {
+		err = st.teams.change(cmd.actorID, cmd.team)
+	} else if len(st.members) == 0 || st.members[0] != cmd.actorID {
+		err = ErrNotRoomMaster
+	} else {
+		err = st.teams.swap(cmd.target, cmd.swapWith)
+	}
+	cmd.out <- err
+
+	if err == nil {
+		st.broadcast(TeamChangedEvent{Teams: st.teams.snapshot()}, nil)
+	}
+}
+
+func (st *roomState) request(cmd roomRequestCmd) {
+	req := cmd.req
+	key := requestKey{requester: req.Sender, requestID: req.RequestID}
+	if _, ok := st.pending[key]; ok {
+		// the pending request keeps its only reply
+		cmd.out <- ErrRequestDuplicated
+		return
+	}
+	cmd.out <- nil
+
+	p := pendingRequest{target: req.Target, correlationID: req.CorrelationID}
+	var target *outbox
+	if len(req.Target) == 0 {
+		if st.opts.RequestHandler == nil {
+			st.fail(key, p, ErrRequestNotHandled)
+			return
+		}
+	} else if target = st.subscribers[req.Target]; target == nil {
+		st.fail(key, p, ErrRequestTargetNotFound)
+		return
+	}
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = st.opts.RequestTimeout
+	}
+	p.timer = time.AfterFunc(timeout, func() {
+		st.r.post(roomExpiredCmd{key: key})
+	})
+	if target != nil {
+		st.pending[key] = p
+		target.push(req)
+		return
+	}
+
+	// the handler runs off the room; its response comes back through the mailbox
+	p.ctx, p.cancel = context.WithTimeout(context.Background(), timeout)
+	st.pending[key] = p
+	handler := st.opts.RequestHandler
+	go func(ctx context.Context) {
+		res, err := handler(ctx, req)
+		st.r.post(roomHandledCmd{
+			key: key,
+			ctx: ctx,
+			res: ResponseMessage{Requester: req.Sender, RequestID: req.RequestID, Code: res.Code, Payload: res.Body, Err: err, CorrelationID: req.CorrelationID},
+		})
+	}(p.ctx)
+}
+
+func (st *roomState) respond(cmd roomResponseCmd) {
+	key := requestKey{requester: cmd.res.Requester, requestID: cmd.res.RequestID}
+	p, ok := st.pending[key]
+	if !ok || p.target != cmd.res.Sender {
+		cmd.out <- ErrRequestNotPending
+		return
+	}
+	p.timer.Stop()
+	delete(st.pending, key)
+	cmd.out <- nil
+	cmd.res.CorrelationID = p.correlationID
+	st.reply(cmd.res)
+}
+
+func (st *roomState) reply(res ResponseMessage) {
+	if s, ok := st.subscribers[res.Requester]; ok {
+		s.push(res)
+	}
+}
+
+func (st *roomState) fail(key requestKey, p pendingRequest, err error) {
+	st.reply(ResponseMessage{Sender: p.target, Requester: key.requester, RequestID: key.requestID, Err: err, CorrelationID: p.correlationID})
+}
+
+func (st *roomState) setProperties(cmd roomPropsCmd) {
+	if _, ok := st.subscribers[cmd.actorID]; !ok {
+		return
+	}
+	current, ok := st.properties[cmd.actorID]
+	if !ok {
+		current = map[string]string{}
+		st.properties[cmd.actorID] = current
+	}
+	for k, v := range cmd.properties {
+		current[k] = v
+	}
+	st.broadcast(ActorPropertiesChangedEvent{Actor: cmd.actorID, Properties: copyProperties(current)}, nil)
+}
+
+func (st *roomState) message(m ActorMessage) {
+	s, ok := st.subscribers[m.Sender]
+	if !ok {
+		return
+	}
+	delivered := true
+	if len(st.opts.Interceptors) == 0 {
+		st.deliver(m)
+	} else {
+		var err error
+		if delivered, err = st.intercept(m); err != nil {
+			s.push(MessageRejectedEvent{Message: m, Err: err})
+			return
+		}
+	}
+	// a message dropped by the interceptors is not acked
+	if m.Ack && delivered {
+		s.push(MessageAcceptedEvent{Message: m})
+	}
+}
+
+func (st *roomState) deliver(m ActorMessage) {
+	if !m.TeamOnly {
+		st.broadcast(m, nil)
+		return
+	}
+	senderTeam := st.teams.teamOf(m.Sender)
+	st.broadcast(m, func(id ActorID) bool {
+		return st.teams.teamOf(id) == senderTeam
+	})
+}
+
+// broadcast delivers m to the members accepted by to, or to all members if to is nil.
+// Large rooms are split into chunks which are delivered in parallel.
+func (st *roomState) broadcast(m Message, to func(ActorID) bool) {
+	deliver := func(ids []ActorID) {
+		for _, id := range ids {
+			if to == nil || to(id) {
+				st.subscribers[id].push(m)
 			}
 		}
-	}()
-	return &Room{
-		join: join, leave: leave, messages: messages,
-		done: done,
 	}
+	if len(st.members) < parallelFanoutThreshold {
+		deliver(st.members)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < len(st.members); i += fanoutChunkSize {
+		end := i + fanoutChunkSize
+		if len(st.members) < end {
+			end = len(st.members)
+		}
+		wg.Add(1)
+		go func(ids []ActorID) {
+			defer wg.Done()
+			deliver(ids)
+		}(st.members[i:end])
+	}
+	wg.Wait()
 }
 
-func (r *Room) NewEntry(actorID ActorID) *RoomEntry {
-	out := make(chan (chan Message))
-	defer close(out)
-	r.join <- roomJoinCmd{actorID: actorID, out: out}
-	return &RoomEntry{id: actorID, r: r, s: <-out}
+func (st *roomState) currentActors() []ActorID {
+	s := make([]ActorID, 0, len(st.subscribers))
+	for id := range st.subscribers {
+		s = append(s, id)
+	}
+	return s
 }
 
-func (r *Room) Stop() {
-	r.done <- struct{}{}
+func (st *roomState) currentBots() []ActorID {
+	s := make([]ActorID, 0, len(st.bots))
+	for id := range st.bots {
+		s = append(s, id)
+	}
+	return s
+}
+
+func copyProperties(p map[string]string) map[string]string {
+	m := make(map[string]string, len(p))
+	for k, v := range p {
+		m[k] = v
+	}
+	return m
 }
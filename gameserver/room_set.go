@@ -1,7 +1,8 @@
 package gameserver
 
 import (
-	"math/rand"
+	"context"
+	"errors"
 	"sync"
 
 	"github.com/google/uuid"
@@ -9,9 +10,15 @@ import (
 	"quark"
 )
 
+var (
+	ErrRoomNotFound = errors.New("room not found")
+	ErrBotNotFound  = errors.New("bot not found")
+)
+
 type RoomSet struct {
 	rooms map[quark.RoomID]*Room
 	names map[string]quark.RoomID
+	bots  map[ActorID]botHandle
 
 	mux sync.RWMutex
 }
@@ -20,10 +27,14 @@ func NewRoomSet() *RoomSet {
 	return &RoomSet{
 		rooms: make(map[quark.RoomID]*Room),
 		names: make(map[string]quark.RoomID),
+		bots:  make(map[ActorID]botHandle),
 	}
 }
 
 func (s *RoomSet) Rooms() []*Room {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
 	rs := make([]*Room, 0, len(s.rooms))
 	for _, r := range s.rooms {
 		rs = append(rs, r)
@@ -31,7 +42,7 @@ func (s *RoomSet) Rooms() []*Room {
 	return rs
 }
 
-func (s *RoomSet) NewRoom(name string) (quark.RoomID, bool) {
+func (s *RoomSet) NewRoom(name string, opts RoomOptions) (quark.RoomID, bool) {
 	if len(name) == 0 {
 		name = uuid.Must(uuid.NewRandom()).String()
 	}
@@ -50,8 +61,10 @@ func (s *RoomSet) NewRoom(name string) (quark.RoomID, bool) {
 		return id, true
 	}
 
-	newID := quark.RoomID(rand.Uint64())
-	room := NewRoom()
+	newID := quark.NewRoomID()
+	room := newRoom(newID, opts, func() {
+		s.removeRoom(newID)
+	})
 
 	func() {
 		s.mux.Lock()
@@ -63,6 +76,54 @@ func (s *RoomSet) NewRoom(name string) (quark.RoomID, bool) {
 	return newID, false
 }
 
+func (s *RoomSet) removeRoom(id quark.RoomID) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.rooms, id)
+	for name, roomID := range s.names {
+		if roomID == id {
+			delete(s.names, name)
+		}
+	}
+	for _, b := range s.bots {
+		if b.roomID == id {
+			b.cancel()
+		}
+	}
+}
+
+// CloseRoom closes the room and removes it from the set.
+func (s *RoomSet) CloseRoom(ctx context.Context, id quark.RoomID, reason LeaveReason, detail string) error {
+	r, ok := s.GetRoom(id)
+	if !ok {
+		return ErrRoomNotFound
+	}
+	err := r.Close(ctx, reason, detail)
+	if err == nil || err == ErrRoomClosed {
+		s.removeRoom(id)
+	}
+	return err
+}
+
+// CloseAll closes every room in the set, e.g. on server shutdown.
+func (s *RoomSet) CloseAll(ctx context.Context, reason LeaveReason, detail string) error {
+	s.mux.RLock()
+	ids := make([]quark.RoomID, 0, len(s.rooms))
+	for id := range s.rooms {
+		ids = append(ids, id)
+	}
+	s.mux.RUnlock()
+
+	for _, id := range ids {
+		err := s.CloseRoom(ctx, id, reason, detail)
+		if err != nil && err != ErrRoomNotFound && err != ErrRoomClosed {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *RoomSet) GetRoom(id quark.RoomID) (*Room, bool) {
 	s.mux.RLock()
 	defer s.mux.RUnlock()
@@ -74,11 +135,84 @@ func (s *RoomSet) GetRoom(id quark.RoomID) (*Room, bool) {
 	}
 }
 
-func (s *RoomSet) JoinRoom(roomID quark.RoomID, a *Actor) bool {
+func (s *RoomSet) JoinRoom(ctx context.Context, roomID quark.RoomID, a *Actor) error {
 	r, ok := s.GetRoom(roomID)
 	if !ok {
-		return false
+		return ErrRoomNotFound
+	}
+	return a.JoinTo(ctx, r)
+}
+
+// AddBot spawns a bot actor in the room and runs fn until the bot is removed.
+func (s *RoomSet) AddBot(ctx context.Context, roomID quark.RoomID, fn BotFunc) (ActorID, error) {
+	r, ok := s.GetRoom(roomID)
+	if !ok {
+		return "", ErrRoomNotFound
+	}
+
+	a := NewBot()
+	if err := a.JoinTo(ctx, r); err != nil {
+		return "", err
+	}
+
+	// the bot outlives ctx, which only bounds joining the room
+	botCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	s.mux.Lock()
+	s.bots[a.ActorID()] = botHandle{roomID: roomID, cancel: cancel, done: done}
+	// the room may have been removed after the bot joined, without canceling it
+	if s.rooms[roomID] != r {
+		cancel()
+	}
+	s.mux.Unlock()
+
+	go func() {
+		defer close(done)
+		defer func() {
+			s.mux.Lock()
+			defer s.mux.Unlock()
+			delete(s.bots, a.ActorID())
+		}()
+		defer func() {
+			if botCtx.Err() != nil {
+				a.LeaveWithReason(context.Background(), roomID, LeaveReasonKicked, "bot removed")
+			} else {
+				a.Leave(context.Background(), roomID)
+			}
+		}()
+
+		fn(botCtx, roomID, a)
+	}()
+	return a.ActorID(), nil
+}
+
+// RemoveBot stops the bot and waits until it leaves the room.
+func (s *RoomSet) RemoveBot(ctx context.Context, actorID ActorID) error {
+	s.mux.RLock()
+	b, ok := s.bots[actorID]
+	s.mux.RUnlock()
+	if !ok {
+		return ErrBotNotFound
+	}
+	b.cancel()
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *RoomSet) Bots(roomID quark.RoomID) []ActorID {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	ids := make([]ActorID, 0)
+	for id, b := range s.bots {
+		if b.roomID == roomID {
+			ids = append(ids, id)
+		}
 	}
-	a.JoinTo(r)
-	return true
+	return ids
 }
@@ -0,0 +1,108 @@
+package gameserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quark"
+)
+
+func TestRoomSet_AddBot(t *testing.T) {
+	s := NewRoomSet()
+	roomID, _ := s.NewRoom("bot", RoomOptions{})
+	r, ok := s.GetRoom(roomID)
+	require.True(t, ok)
+	defer r.Stop()
+
+	ctx := context.Background()
+	a := NewActor()
+	require.NoError(t, a.JoinTo(ctx, r))
+
+	// echo bot
+	botID, err := s.AddBot(ctx, roomID, func(ctx context.Context, roomID quark.RoomID, bot *Actor) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m := <-bot.Inbox(roomID):
+				if m, ok := m.(ActorMessage); ok && !bot.IsOwnMessage(&m) {
+					bot.BroadcastToRoom(ctx, roomID, Payload{Code: m.Code, Body: m.Payload})
+				}
+			}
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []ActorID{botID}, s.Bots(roomID))
+	{
+		m := <-a.Inbox(roomID)
+		require.IsType(t, m, JoinRoomEvent{})
+		ev := m.(JoinRoomEvent)
+		assert.Equal(t, botID, ev.NewActor)
+		assert.Equal(t, []ActorID{botID}, ev.BotList)
+	}
+
+	a.BroadcastToRoom(ctx, roomID, Payload{0x01, []byte("hello")})
+	<-a.Inbox(roomID)
+	{
+		m := <-a.Inbox(roomID)
+		require.IsType(t, m, ActorMessage{})
+		assert.Equal(t, botID, m.(ActorMessage).Sender)
+		assert.Equal(t, []byte("hello"), m.(ActorMessage).Payload)
+	}
+
+	require.NoError(t, s.RemoveBot(ctx, botID))
+	assert.Empty(t, s.Bots(roomID))
+	{
+		m := <-a.Inbox(roomID)
+		require.IsType(t, m, LeaveRoomEvent{})
+		ev := m.(LeaveRoomEvent)
+		assert.Equal(t, botID, ev.RemovedActor)
+		assert.Empty(t, ev.BotList)
+	}
+	assert.Equal(t, ErrBotNotFound, s.RemoveBot(ctx, botID))
+
+	_, err = s.AddBot(ctx, 0, func(ctx context.Context, roomID quark.RoomID, a *Actor) {})
+	assert.Equal(t, ErrRoomNotFound, err)
+}
+
+func TestRoomSet_CloseRoom(t *testing.T) {
+	s := NewRoomSet()
+	roomID, _ := s.NewRoom("close", RoomOptions{})
+	r, ok := s.GetRoom(roomID)
+	require.True(t, ok)
+
+	ctx := context.Background()
+	a1 := NewActor()
+	require.NoError(t, a1.JoinTo(ctx, r))
+
+	require.NoError(t, s.CloseRoom(ctx, roomID, LeaveReasonServerShutdown, "maintenance"))
+	{
+		m := <-a1.Inbox(roomID)
+		require.IsType(t, m, RoomClosedEvent{})
+		ev := m.(RoomClosedEvent)
+		assert.Equal(t, LeaveReasonServerShutdown, ev.Reason)
+		assert.Equal(t, "maintenance", ev.Detail)
+
+		_, ok := <-a1.Inbox(roomID)
+		assert.False(t, ok)
+	}
+
+	_, ok = s.GetRoom(roomID)
+	assert.False(t, ok)
+	assert.Equal(t, ErrRoomNotFound, s.CloseRoom(ctx, roomID, LeaveReasonRoomClosed, ""))
+
+	assert.Equal(t, ErrRoomClosed, a1.BroadcastToRoom(ctx, roomID, Payload{0x01, nil}))
+
+	a2 := NewActor()
+	assert.Equal(t, ErrRoomClosed, a2.JoinTo(ctx, r))
+
+	// closing twice must not block
+	assert.Equal(t, ErrRoomClosed, r.Close(ctx, LeaveReasonRoomClosed, ""))
+
+	newID, loaded := s.NewRoom("close", RoomOptions{})
+	assert.False(t, loaded)
+	assert.NotEqual(t, roomID, newID)
+}
@@ -0,0 +1,471 @@
+package gameserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoom_LargeBroadcast(t *testing.T) {
+	r := NewRoom(RoomOptions{})
+	defer r.Stop()
+
+	ctx := context.Background()
+	entries := make([]*RoomEntry, 1000)
+	for i := range entries {
+		e, err := r.NewEntry(ctx, NewActorID())
+		require.NoError(t, err)
+		entries[i] = e
+	}
+
+	sender := entries[0]
+	for i := 0; i < 10; i++ {
+		require.NoError(t, sender.Send(ctx, ActorMessage{Sender: sender.id, Code: uint32(i)}))
+	}
+
+	var wg sync.WaitGroup
+	for _, e := range entries {
+		wg.Add(1)
+		go func(e *RoomEntry) {
+			defer wg.Done()
+			code := uint32(0)
+			for m := range e.Subscription() {
+				if m, ok := m.(ActorMessage); ok {
+					assert.Equal(t, code, m.Code)
+					code += 1
+					if code == 10 {
+						return
+					}
+				}
+			}
+		}(e)
+	}
+	wg.Wait()
+}
+
+func TestRoom_SlowSubscriber(t *testing.T) {
+	r := NewRoom(RoomOptions{})
+	defer r.Stop()
+
+	ctx := context.Background()
+	a1 := NewActor()
+	a2 := NewActor()
+	require.NoError(t, a1.JoinTo(ctx, r))
+	require.NoError(t, a2.JoinTo(ctx, r))
+	<-a1.Inbox(r.ID())
+
+	// a1 does not read while a2 keeps receiving
+	n := maxQueuedMessages / 2
+	for i := 0; i < n; i++ {
+		require.NoError(t, a2.BroadcastToRoom(ctx, r.ID(), Payload{Code: uint32(i)}))
+		m := <-a2.Inbox(r.ID())
+		assert.EqualValues(t, i, m.(ActorMessage).Code)
+	}
+	for i := 0; i < n; i++ {
+		m := <-a1.Inbox(r.ID())
+		assert.EqualValues(t, i, m.(ActorMessage).Code)
+	}
+
+	require.NoError(t, a2.BroadcastToRoom(ctx, r.ID(), Payload{Code: uint32(n)}))
+	r.Stop()
+	for m := range a1.Inbox(r.ID()) {
+		if m, ok := m.(ActorMessage); ok {
+			assert.EqualValues(t, n, m.Code)
+		}
+	}
+}
+
+func BenchmarkRoom_Idle(b *testing.B) {
+	ctx := context.Background()
+	sched := NewScheduler(0)
+	defer sched.Stop()
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	goroutines := runtime.NumGoroutine()
+
+	rooms := make([]*Room, b.N)
+	for i := range rooms {
+		rooms[i] = NewRoom(RoomOptions{Scheduler: sched})
+		if _, err := rooms[i].NewEntry(ctx, NewActorID()); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(b.N), "B/room")
+	b.ReportMetric(float64(runtime.NumGoroutine()-goroutines)/float64(b.N), "goroutines/room")
+
+	b.StopTimer()
+	for _, r := range rooms {
+		r.Stop()
+	}
+}
+
+func BenchmarkRoom_Broadcast1k(b *testing.B) {
+	r := NewRoom(RoomOptions{})
+	defer r.Stop()
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	entries := make([]*RoomEntry, 1000)
+	for i := range entries {
+		e, err := r.NewEntry(ctx, NewActorID())
+		if err != nil {
+			b.Fatal(err)
+		}
+		entries[i] = e
+	}
+	for _, e := range entries {
+		go func(e *RoomEntry) {
+			for m := range e.Subscription() {
+				if _, ok := m.(ActorMessage); ok {
+					wg.Done()
+				}
+			}
+		}(e)
+	}
+
+	sender := entries[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// the time until every subscriber has received the message
+		wg.Add(len(entries))
+		if err := sender.Send(ctx, ActorMessage{Sender: sender.id, Code: uint32(i)}); err != nil {
+			b.Fatal(err)
+		}
+		wg.Wait()
+	}
+}
+
+func TestRoom_CoalesceKey(t *testing.T) {
+	r := NewRoom(RoomOptions{})
+	defer r.Stop()
+
+	ctx := context.Background()
+	a1 := NewActor()
+	a2 := NewActor()
+	require.NoError(t, a1.JoinTo(ctx, r))
+	require.NoError(t, a2.JoinTo(ctx, r))
+	<-a1.Inbox(r.ID())
+
+	send := func(p Payload, opts ...MessageOptions) {
+		require.NoError(t, a2.BroadcastToRoom(ctx, r.ID(), p, opts...))
+		<-a2.Inbox(r.ID())
+	}
+
+	// a1 is a few messages behind
+	for i := 0; i < inboxSize+2; i++ {
+		send(Payload{Code: 0})
+	}
+	for i := 1; i <= 10; i++ {
+		send(Payload{Code: uint32(i)}, MessageOptions{CoalesceKey: "pos"})
+		send(Payload{Code: 100, Body: []byte{byte(i)}}, MessageOptions{CoalesceKey: "hp"})
+	}
+	send(Payload{Code: 200})
+
+	for i := 0; i < inboxSize+2; i++ {
+		<-a1.Inbox(r.ID())
+	}
+	var got []uint32
+	for i := 0; i < 3; i++ {
+		m := <-a1.Inbox(r.ID())
+		got = append(got, m.(ActorMessage).Code)
+		if m.(ActorMessage).Code == 100 {
+			assert.Equal(t, []byte{10}, m.(ActorMessage).Payload)
+		}
+	}
+	assert.Equal(t, []uint32{10, 100, 200}, got)
+
+	select {
+	case m := <-a1.Inbox(r.ID()):
+		t.Fatalf("unexpected message: %v", m)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestRoom_CoalesceKey_Behind(t *testing.T) {
+	r := NewRoom(RoomOptions{})
+	defer r.Stop()
+
+	ctx := context.Background()
+	a1 := NewActor()
+	a2 := NewActor()
+	require.NoError(t, a1.JoinTo(ctx, r))
+	require.NoError(t, a2.JoinTo(ctx, r))
+	<-a1.Inbox(r.ID())
+
+	// only the updates which fit in the channel can be outdated
+	for i := 1; i <= 100; i++ {
+		require.NoError(t, a2.BroadcastToRoom(ctx, r.ID(), Payload{Code: uint32(i)}, MessageOptions{CoalesceKey: "pos"}))
+		<-a2.Inbox(r.ID())
+	}
+	var got []uint32
+	for len(got) == 0 || got[len(got)-1] != 100 {
+		got = append(got, (<-a1.Inbox(r.ID())).(ActorMessage).Code)
+	}
+	assert.LessOrEqual(t, len(got), inboxSize+1)
+}
+
+func TestRoom_Overflow(t *testing.T) {
+	r := NewRoom(RoomOptions{})
+	defer r.Stop()
+
+	ctx := context.Background()
+	a1 := NewActor()
+	a2 := NewActor()
+	require.NoError(t, a1.JoinTo(ctx, r))
+	require.NoError(t, a2.JoinTo(ctx, r))
+	<-a1.Inbox(r.ID())
+
+	// a1 does not read until it is removed
+	n := inboxSize + maxQueuedMessages + 1
+	for i := 0; i < n; i++ {
+		require.NoError(t, a2.BroadcastToRoom(ctx, r.ID(), Payload{Code: uint32(i)}))
+		<-a2.Inbox(r.ID())
+	}
+	{
+		m := <-a2.Inbox(r.ID())
+		require.IsType(t, LeaveRoomEvent{}, m)
+		assert.Equal(t, a1.ActorID(), m.(LeaveRoomEvent).RemovedActor)
+		assert.Equal(t, LeaveReasonDisconnected, m.(LeaveRoomEvent).Reason)
+	}
+
+	var last Message
+	count := 0
+	for m := range a1.Inbox(r.ID()) {
+		last = m
+		count++
+	}
+	assert.Equal(t, n, count)
+	require.IsType(t, LeaveRoomEvent{}, last)
+	assert.Equal(t, a1.ActorID(), last.(LeaveRoomEvent).RemovedActor)
+}
+
+func TestRoom_FinalEventBehind(t *testing.T) {
+	r := NewRoom(RoomOptions{})
+
+	ctx := context.Background()
+	a1 := NewActor()
+	a2 := NewActor()
+	a3 := NewActor()
+	require.NoError(t, a1.JoinTo(ctx, r))
+	require.NoError(t, a2.JoinTo(ctx, r))
+	require.NoError(t, a3.JoinTo(ctx, r))
+
+	// a1 and a2 fall behind
+	for i := 0; i < 10; i++ {
+		require.NoError(t, a3.BroadcastToRoom(ctx, r.ID(), Payload{Code: uint32(i)}))
+		<-a3.Inbox(r.ID())
+	}
+	require.NoError(t, r.Kick(ctx, a1.ActorID(), "bye"))
+	r.Stop()
+
+	var last Message
+	for m := range a1.Inbox(r.ID()) {
+		last = m
+	}
+	require.IsType(t, LeaveRoomEvent{}, last)
+	assert.Equal(t, LeaveReasonKicked, last.(LeaveRoomEvent).Reason)
+
+	for m := range a2.Inbox(r.ID()) {
+		last = m
+	}
+	require.IsType(t, RoomClosedEvent{}, last)
+}
+
+func TestRoom_Interceptors(t *testing.T) {
+	errSpam := errors.New("spam")
+	var infos []MessageInfo
+	r := NewRoom(RoomOptions{
+		Interceptors: []MessageInterceptor{
+			func(info MessageInfo, m ActorMessage, next MessageHandler) error {
+				infos = append(infos, info)
+				if m.Code == 0xff {
+					return errSpam
+				}
+				return next(m)
+			},
+			func(info MessageInfo, m ActorMessage, next MessageHandler) error {
+				if m.Code == 0 {
+					// drop
+					return nil
+				}
+				m.Payload = bytes.ToUpper(m.Payload)
+				return next(m)
+			},
+		},
+	})
+	defer r.Stop()
+
+	ctx := context.Background()
+	a1 := NewActor()
+	a2 := NewActorForUser("user-2")
+	require.NoError(t, a1.JoinTo(ctx, r))
+	require.NoError(t, a2.JoinTo(ctx, r))
+	<-a1.Inbox(r.ID())
+
+	require.NoError(t, a2.BroadcastToRoom(ctx, r.ID(), Payload{Code: 0xff}))
+	{
+		m := <-a2.Inbox(r.ID())
+		require.IsType(t, m, MessageRejectedEvent{})
+		ev := m.(MessageRejectedEvent)
+		assert.Equal(t, errSpam, ev.Err)
+		assert.EqualValues(t, 0xff, ev.Message.Code)
+	}
+
+	require.NoError(t, a2.BroadcastToRoom(ctx, r.ID(), Payload{Code: 0, Body: []byte("dropped")}))
+	require.NoError(t, a2.BroadcastToRoom(ctx, r.ID(), Payload{Code: 1, Body: []byte("hello")}))
+	for _, a := range []*Actor{a1, a2} {
+		m := <-a.Inbox(r.ID())
+		require.IsType(t, m, ActorMessage{})
+		assert.EqualValues(t, 1, m.(ActorMessage).Code)
+		assert.Equal(t, []byte("HELLO"), m.(ActorMessage).Payload)
+	}
+
+	require.Len(t, infos, 3)
+	assert.Equal(t, MessageInfo{Room: r.ID(), Sender: a2.ActorID(), User: "user-2"}, infos[0])
+}
+
+func TestRoom_InterceptorsRewrite(t *testing.T) {
+	var a2 *Actor
+	r := NewRoom(RoomOptions{
+		TeamCount: 2,
+		TeamSize:  1,
+		Interceptors: []MessageInterceptor{
+			func(info MessageInfo, m ActorMessage, next MessageHandler) error {
+				m.Sender = a2.ActorID()
+				m.TeamOnly = false
+				m.Code = 2
+				m.Payload = bytes.ToUpper(m.Payload)
+				return next(m)
+			},
+		},
+	})
+	defer r.Stop()
+
+	ctx := context.Background()
+	a1 := NewActor()
+	a2 = NewActor()
+	require.NoError(t, a1.JoinTo(ctx, r))
+	require.NoError(t, a2.JoinTo(ctx, r))
+	<-a1.Inbox(r.ID())
+
+	require.NoError(t, a1.BroadcastToTeam(ctx, r.ID(), Payload{Code: 1, Body: []byte("team")}))
+	m := <-a1.Inbox(r.ID())
+	require.IsType(t, ActorMessage{}, m)
+	assert.Equal(t, a1.ActorID(), m.(ActorMessage).Sender)
+	assert.True(t, m.(ActorMessage).TeamOnly)
+	assert.EqualValues(t, 2, m.(ActorMessage).Code)
+	assert.Equal(t, []byte("TEAM"), m.(ActorMessage).Payload)
+	select {
+	case m := <-a2.Inbox(r.ID()):
+		t.Fatalf("unexpected message %v", m)
+	default:
+	}
+}
+
+func TestRoom_InterceptorsAck(t *testing.T) {
+	r := NewRoom(RoomOptions{
+		Interceptors: []MessageInterceptor{
+			func(info MessageInfo, m ActorMessage, next MessageHandler) error {
+				if m.Code == 0 {
+					// drop
+					return nil
+				}
+				return next(m)
+			},
+		},
+	})
+	defer r.Stop()
+
+	ctx := context.Background()
+	a := NewActor()
+	require.NoError(t, a.JoinTo(ctx, r))
+
+	require.NoError(t, a.BroadcastToRoom(ctx, r.ID(), Payload{Code: 0}, MessageOptions{CorrelationID: 1, Ack: true}))
+	require.NoError(t, a.BroadcastToRoom(ctx, r.ID(), Payload{Code: 1}, MessageOptions{CorrelationID: 2, Ack: true}))
+
+	// the dropped message is neither delivered nor acked
+	m := <-a.Inbox(r.ID())
+	require.IsType(t, ActorMessage{}, m)
+	assert.EqualValues(t, 2, m.(ActorMessage).CorrelationID)
+	m = <-a.Inbox(r.ID())
+	require.IsType(t, MessageAcceptedEvent{}, m)
+	assert.EqualValues(t, 2, m.(MessageAcceptedEvent).Message.CorrelationID)
+}
+
+func TestRoom_ReservedSeats(t *testing.T) {
+	r := NewRoom(RoomOptions{MaxActors: 3, ReservedUserIDs: []string{"alice", "bob"}})
+	defer r.Stop()
+
+	ctx := context.Background()
+	require.NoError(t, NewActor().JoinTo(ctx, r))
+	assert.Equal(t, ErrRoomFull, NewActor().JoinTo(ctx, r))
+	assert.Equal(t, ErrRoomFull, NewActorForUser("carol").JoinTo(ctx, r))
+
+	require.NoError(t, NewActorForUser("alice").JoinTo(ctx, r))
+	// the reservation is used up
+	assert.Equal(t, ErrRoomFull, NewActorForUser("alice").JoinTo(ctx, r))
+	require.NoError(t, NewActorForUser("bob").JoinTo(ctx, r))
+}
+
+func TestRoom_ReservationTTL(t *testing.T) {
+	r := NewRoom(RoomOptions{MaxActors: 1, ReservedUserIDs: []string{"alice"}, ReservationTTL: 50 * time.Millisecond})
+	defer r.Stop()
+
+	ctx := context.Background()
+	assert.Equal(t, ErrRoomFull, NewActor().JoinTo(ctx, r))
+
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, NewActor().JoinTo(ctx, r))
+	assert.Equal(t, ErrRoomFull, NewActorForUser("alice").JoinTo(ctx, r))
+}
+
+func TestRoom_Reserve(t *testing.T) {
+	r := NewRoom(RoomOptions{MaxActors: 3})
+	defer r.Stop()
+
+	ctx := context.Background()
+	require.NoError(t, NewActor().JoinTo(ctx, r))
+	require.NoError(t, r.Reserve(ctx, []string{"alice", "bob"}, 0))
+	assert.Equal(t, ErrRoomFull, r.Reserve(ctx, []string{"carol"}, 0))
+	// renewing a reservation takes no seat
+	require.NoError(t, r.Reserve(ctx, []string{"alice"}, time.Minute))
+
+	assert.Equal(t, ErrRoomFull, NewActor().JoinTo(ctx, r))
+	require.NoError(t, NewActorForUser("bob").JoinTo(ctx, r))
+	require.NoError(t, NewActorForUser("alice").JoinTo(ctx, r))
+}
+
+func TestRoom_UserTeams(t *testing.T) {
+	r := NewRoom(RoomOptions{TeamCount: 2, UserTeams: map[string]TeamID{"alice": 2, "bob": 2}})
+	defer r.Stop()
+
+	ctx := context.Background()
+	alice := NewActorForUser("alice")
+	bob := NewActorForUser("bob")
+	carol := NewActorForUser("carol")
+	require.NoError(t, alice.JoinTo(ctx, r))
+	require.NoError(t, bob.JoinTo(ctx, r))
+	require.NoError(t, carol.JoinTo(ctx, r))
+
+	var teams map[ActorID]TeamID
+	for i := 0; i < 2; i++ {
+		m := <-alice.Inbox(r.ID())
+		require.IsType(t, m, JoinRoomEvent{})
+		teams = m.(JoinRoomEvent).Teams
+	}
+	assert.Equal(t, TeamID(2), teams[alice.ActorID()])
+	assert.Equal(t, TeamID(2), teams[bob.ActorID()])
+	assert.Equal(t, TeamID(1), teams[carol.ActorID()])
+}
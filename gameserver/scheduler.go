@@ -0,0 +1,102 @@
+package gameserver
+
+import (
+	"runtime"
+	"sync"
+)
+
+// roomBatchSize is the number of commands a room handles before yielding its shard to other rooms.
+const roomBatchSize = 64
+
+// Scheduler runs the mailboxes of rooms on a fixed number of shards.
+// A room is pinned to one shard and only occupies it while it has pending commands,
+// so idle rooms cost no goroutines.
+type Scheduler struct {
+	shards []*shard
+	done   chan struct{}
+	once   sync.Once
+}
+
+type shard struct {
+	mu    sync.Mutex
+	queue []*Room
+	wake  chan struct{}
+}
+
+// NewScheduler starts a scheduler with n shards; n <= 0 means GOMAXPROCS.
+func NewScheduler(n int) *Scheduler {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	s := &Scheduler{
+		shards: make([]*shard, n),
+		done:   make(chan struct{}),
+	}
+	for i := range s.shards {
+		sh := &shard{wake: make(chan struct{}, 1)}
+		s.shards[i] = sh
+		go sh.run(s.done)
+	}
+	return s
+}
+
+var (
+	defaultScheduler     *Scheduler
+	defaultSchedulerOnce sync.Once
+)
+
+// DefaultScheduler returns the scheduler used by rooms without their own.
+func DefaultScheduler() *Scheduler {
+	defaultSchedulerOnce.Do(func() {
+		defaultScheduler = NewScheduler(0)
+	})
+	return defaultScheduler
+}
+
+// Stop stops the shards; rooms on the scheduler no longer handle commands.
+func (s *Scheduler) Stop() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *Scheduler) schedule(r *Room) {
+	s.shards[uint64(r.id)%uint64(len(s.shards))].push(r)
+}
+
+func (sh *shard) push(r *Room) {
+	sh.mu.Lock()
+	sh.queue = append(sh.queue, r)
+	sh.mu.Unlock()
+
+	select {
+	case sh.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (sh *shard) run(done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-sh.wake:
+		}
+
+		for {
+			sh.mu.Lock()
+			rooms := sh.queue
+			sh.queue = nil
+			sh.mu.Unlock()
+			if len(rooms) == 0 {
+				break
+			}
+
+			for _, r := range rooms {
+				if r.run(roomBatchSize) {
+					sh.push(r)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package gameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	ErrInvalidPayload = errors.New("invalid payload")
+	ErrSchemaNotFound = errors.New("no schema for the message code")
+)
+
+// PayloadError is returned for a payload which does not match the schema of its code.
+type PayloadError struct {
+	Code   uint32
+	Reason string
+}
+
+func (e *PayloadError) Error() string {
+	return fmt.Sprintf("invalid payload for code %d: %s", e.Code, e.Reason)
+}
+
+func (e *PayloadError) Is(target error) bool {
+	return target == ErrInvalidPayload
+}
+
+// PayloadSchema validates the payloads of a message code and decodes them into readable form.
+type PayloadSchema interface {
+	Validate(payload []byte) error
+	// Decode returns the payload as JSON
+	Decode(payload []byte) ([]byte, error)
+}
+
+// SchemaRegistry holds the payload schemas by message code, e.g. for a room type.
+type SchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[uint32]PayloadSchema
+}
+
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: make(map[uint32]PayloadSchema)}
+}
+
+func (r *SchemaRegistry) Register(code uint32, s PayloadSchema) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[code] = s
+}
+
+func (r *SchemaRegistry) Lookup(code uint32) (PayloadSchema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.schemas[code]
+	return s, ok
+}
+
+// Validate checks the payload against the schema of the code; codes without a schema are valid.
+func (r *SchemaRegistry) Validate(code uint32, payload []byte) error {
+	s, ok := r.Lookup(code)
+	if !ok {
+		return nil
+	}
+	if err := s.Validate(payload); err != nil {
+		return &PayloadError{Code: code, Reason: err.Error()}
+	}
+	return nil
+}
+
+// Decode returns the payload as JSON, e.g. for admin tools and recordings.
+func (r *SchemaRegistry) Decode(code uint32, payload []byte) ([]byte, error) {
+	s, ok := r.Lookup(code)
+	if !ok {
+		return nil, ErrSchemaNotFound
+	}
+	b, err := s.Decode(payload)
+	if err != nil {
+		return nil, &PayloadError{Code: code, Reason: err.Error()}
+	}
+	return b, nil
+}
+
+// Interceptor rejects messages whose payloads do not match their schemas with a PayloadError.
+func (r *SchemaRegistry) Interceptor() MessageInterceptor {
+	return func(info MessageInfo, m ActorMessage, next MessageHandler) error {
+		if err := r.Validate(m.Code, m.Payload); err != nil {
+			return err
+		}
+		return next(m)
+	}
+}
+
+type protoSchema struct {
+	desc protoreflect.MessageDescriptor
+}
+
+// NewProtoSchema returns a schema of payloads which are the protobuf messages of desc.
+func NewProtoSchema(desc protoreflect.MessageDescriptor) PayloadSchema {
+	return &protoSchema{desc: desc}
+}
+
+// unmarshal decodes the payload, rejecting fields which are not in the schema
+// and missing required fields
+func (s *protoSchema) unmarshal(payload []byte) (proto.Message, error) {
+	m := dynamicpb.NewMessage(s.desc)
+	if err := proto.Unmarshal(payload, m); err != nil {
+		return nil, err
+	}
+	if hasUnknownFields(m) {
+		return nil, errors.New("unknown fields")
+	}
+	if err := proto.CheckInitialized(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func hasUnknownFields(m protoreflect.Message) bool {
+	if len(m.GetUnknown()) != 0 {
+		return true
+	}
+	unknown := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			l := v.List()
+			for i := 0; i < l.Len() && !unknown; i++ {
+				unknown = hasUnknownFields(l.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				unknown = hasUnknownFields(v.Message())
+				return !unknown
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			unknown = hasUnknownFields(v.Message())
+		}
+		return !unknown
+	})
+	return unknown
+}
+
+func (s *protoSchema) Validate(payload []byte) error {
+	_, err := s.unmarshal(payload)
+	return err
+}
+
+func (s *protoSchema) Decode(payload []byte) ([]byte, error) {
+	m, err := s.unmarshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(m)
+}
+
+type jsonSchema struct {
+	root map[string]interface{}
+}
+
+// NewJSONSchema returns a schema of JSON payloads.
+// It supports a subset of JSON Schema: type, properties, required, additionalProperties,
+// items, enum, minimum, maximum, minLength and maxLength.
+func NewJSONSchema(schema []byte) (PayloadSchema, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, err
+	}
+	return &jsonSchema{root: root}, nil
+}
+
+func (s *jsonSchema) Validate(payload []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return err
+	}
+	return validateJSON(s.root, v, "$")
+}
+
+func (s *jsonSchema) Decode(payload []byte) ([]byte, error) {
+	if err := s.Validate(payload); err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := json.Compact(&b, payload); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func validateJSON(schema map[string]interface{}, v interface{}, path string) error {
+	if t, ok := schema["type"].(string); ok && !isJSONType(t, v) {
+		return fmt.Errorf("%s must be %s", path, t)
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s must be one of %v", path, enum)
+		}
+	}
+
+	switch v := v.(type) {
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok && v < min {
+			return fmt.Errorf("%s must be >= %v", path, min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && max < v {
+			return fmt.Errorf("%s must be <= %v", path, max)
+		}
+	case string:
+		n := float64(len([]rune(v)))
+		if min, ok := schema["minLength"].(float64); ok && n < min {
+			return fmt.Errorf("%s must be at least %v characters", path, min)
+		}
+		if max, ok := schema["maxLength"].(float64); ok && max < n {
+			return fmt.Errorf("%s must be at most %v characters", path, max)
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, e := range v {
+				if err := validateJSON(items, e, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, k := range required {
+				if _, ok := v[fmt.Sprint(k)]; !ok {
+					return fmt.Errorf("%s.%v is required", path, k)
+				}
+			}
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		for k, e := range v {
+			if p, ok := props[k].(map[string]interface{}); ok {
+				if err := validateJSON(p, e, path+"."+k); err != nil {
+					return err
+				}
+			} else if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				return fmt.Errorf("%s.%s is not allowed", path, k)
+			}
+		}
+	}
+	return nil
+}
+
+func isJSONType(t string, v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case float64:
+		return t == "number" || (t == "integer" && v == float64(int64(v)))
+	case string:
+		return t == "string"
+	case []interface{}:
+		return t == "array"
+	case map[string]interface{}:
+		return t == "object"
+	default:
+		return false
+	}
+}
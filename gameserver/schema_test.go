@@ -0,0 +1,114 @@
+package gameserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSchemaRegistry_Proto(t *testing.T) {
+	reg := NewSchemaRegistry()
+	reg.Register(0x01, NewProtoSchema((&wrapperspb.StringValue{}).ProtoReflect().Descriptor()))
+
+	payload, err := proto.Marshal(wrapperspb.String("hello"))
+	require.NoError(t, err)
+
+	assert.NoError(t, reg.Validate(0x01, payload))
+	assert.NoError(t, reg.Validate(0x02, []byte{0xff}))
+
+	err = reg.Validate(0x01, []byte{0xff})
+	assert.True(t, errors.Is(err, ErrInvalidPayload))
+
+	// wire-valid payloads with fields which are not in the schema
+	unknown := protowire.AppendTag(nil, 99, protowire.BytesType)
+	unknown = protowire.AppendString(unknown, "hello")
+	err = reg.Validate(0x01, unknown)
+	assert.True(t, errors.Is(err, ErrInvalidPayload))
+
+	reg.Register(0x03, NewProtoSchema((&structpb.ListValue{}).ProtoReflect().Descriptor()))
+	nested := protowire.AppendTag(nil, 1, protowire.BytesType)
+	nested = protowire.AppendBytes(nested, unknown)
+	err = reg.Validate(0x03, nested)
+	assert.True(t, errors.Is(err, ErrInvalidPayload))
+	list, err := proto.Marshal(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("hello")}})
+	require.NoError(t, err)
+	assert.NoError(t, reg.Validate(0x03, list))
+
+	b, err := reg.Decode(0x01, payload)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"hello"`, string(b))
+
+	_, err = reg.Decode(0x02, payload)
+	assert.Equal(t, ErrSchemaNotFound, err)
+}
+
+func TestSchemaRegistry_JSON(t *testing.T) {
+	s, err := NewJSONSchema([]byte(`{
+		"type": "object",
+		"required": ["x", "y"],
+		"additionalProperties": false,
+		"properties": {
+			"x": {"type": "integer", "minimum": 0},
+			"y": {"type": "integer", "minimum": 0},
+			"dir": {"enum": ["n", "e", "s", "w"]},
+			"tags": {"type": "array", "items": {"type": "string", "maxLength": 3}}
+		}
+	}`))
+	require.NoError(t, err)
+	reg := NewSchemaRegistry()
+	reg.Register(0x01, s)
+
+	assert.NoError(t, reg.Validate(0x01, []byte(`{"x": 1, "y": 2, "dir": "n", "tags": ["a"]}`)))
+	for _, p := range []string{
+		`not json`,
+		`[]`,
+		`{"x": 1}`,
+		`{"x": 1.5, "y": 2}`,
+		`{"x": -1, "y": 2}`,
+		`{"x": 1, "y": 2, "dir": "up"}`,
+		`{"x": 1, "y": 2, "tags": ["long"]}`,
+		`{"x": 1, "y": 2, "z": 3}`,
+	} {
+		assert.True(t, errors.Is(reg.Validate(0x01, []byte(p)), ErrInvalidPayload), p)
+	}
+
+	b, err := reg.Decode(0x01, []byte(`{ "x": 1, "y": 2 }`))
+	require.NoError(t, err)
+	assert.Equal(t, `{"x":1,"y":2}`, string(b))
+}
+
+func TestSchemaRegistry_Interceptor(t *testing.T) {
+	s, err := NewJSONSchema([]byte(`{"type": "object"}`))
+	require.NoError(t, err)
+	reg := NewSchemaRegistry()
+	reg.Register(0x01, s)
+
+	r := NewRoom(RoomOptions{Interceptors: []MessageInterceptor{reg.Interceptor()}})
+	defer r.Stop()
+
+	ctx := context.Background()
+	a := NewActor()
+	require.NoError(t, a.JoinTo(ctx, r))
+
+	require.NoError(t, a.BroadcastToRoom(ctx, r.ID(), Payload{Code: 0x01, Body: []byte(`"str"`)}))
+	{
+		m := <-a.Inbox(r.ID())
+		require.IsType(t, m, MessageRejectedEvent{})
+		var perr *PayloadError
+		require.True(t, errors.As(m.(MessageRejectedEvent).Err, &perr))
+		assert.EqualValues(t, 0x01, perr.Code)
+	}
+
+	require.NoError(t, a.BroadcastToRoom(ctx, r.ID(), Payload{Code: 0x01, Body: []byte(`{}`)}))
+	{
+		m := <-a.Inbox(r.ID())
+		require.IsType(t, m, ActorMessage{})
+	}
+}
@@ -0,0 +1,111 @@
+package gameserver
+
+import "errors"
+
+var (
+	ErrTeamNotFound = errors.New("team does not exist")
+	ErrTeamFull     = errors.New("team is full")
+)
+
+type TeamID uint32
+
+const NoTeam TeamID = 0
+
+type teamSet struct {
+	opts    RoomOptions
+	members map[ActorID]TeamID
+}
+
+func newTeamSet(opts RoomOptions) *teamSet {
+	return &teamSet{opts: opts, members: make(map[ActorID]TeamID)}
+}
+
+func (t *teamSet) exists(team TeamID) bool {
+	return team != NoTeam && uint(team) <= t.opts.TeamCount
+}
+
+func (t *teamSet) size(team TeamID) uint {
+	var n uint = 0
+	for _, m := range t.members {
+		if m == team {
+			n += 1
+		}
+	}
+	return n
+}
+
+func (t *teamSet) isFull(team TeamID) bool {
+	return 0 < t.opts.TeamSize && t.opts.TeamSize <= t.size(team)
+}
+
+// assign puts the actor into the team of its user, or else into the smallest
+// team which is not full. The actor belongs to no team if every team is full.
+func (t *teamSet) assign(id ActorID, user string) TeamID {
+	if !t.opts.HasTeams() {
+		return NoTeam
+	}
+	if team, ok := t.opts.UserTeams[user]; ok && len(user) != 0 && t.exists(team) && !t.isFull(team) {
+		t.members[id] = team
+		return team
+	}
+	team := NoTeam
+	var min uint
+	for i := uint(1); i <= t.opts.TeamCount; i++ {
+		candidate := TeamID(i)
+		if t.isFull(candidate) {
+			continue
+		}
+		if n := t.size(candidate); team == NoTeam || n < min {
+			team, min = candidate, n
+		}
+	}
+	t.members[id] = team
+	return team
+}
+
+func (t *teamSet) change(id ActorID, team TeamID) error {
+	current, ok := t.members[id]
+	if !ok {
+		return ErrNotMember
+	}
+	if !t.exists(team) {
+		return ErrTeamNotFound
+	}
+	if current == team {
+		return nil
+	}
+	if t.isFull(team) {
+		return ErrTeamFull
+	}
+	t.members[id] = team
+	return nil
+}
+
+func (t *teamSet) swap(a, b ActorID) error {
+	ta, ok := t.members[a]
+	if !ok {
+		return ErrNotMember
+	}
+	tb, ok := t.members[b]
+	if !ok {
+		return ErrNotMember
+	}
+	t.members[a], t.members[b] = tb, ta
+	return nil
+}
+
+func (t *teamSet) remove(id ActorID) {
+	delete(t.members, id)
+}
+
+func (t *teamSet) teamOf(id ActorID) TeamID {
+	return t.members[id]
+}
+
+func (t *teamSet) snapshot() map[ActorID]TeamID {
+	m := make(map[ActorID]TeamID, len(t.members))
+	for id, team := range t.members {
+		m[id] = team
+	}
+	return m
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+
+	"quark/proto"
+)
+
+// streamSender serializes sends to a Service stream.
+// If batching is enabled, messages are queued and sent as one Batch message
+// once the window has passed or the queue reaches the size cap.
+type streamSender struct {
+	stream proto.Room_ServiceServer
+	abort  func(error)
+	window time.Duration
+	size   int
+
+	mu     sync.Mutex
+	queue  []*proto.ServerMessage
+	timer  *time.Timer
+	closed bool
+}
+
+func newStreamSender(stream proto.Room_ServiceServer, opts RoomServerOptions, abort func(error)) *streamSender {
+	return &streamSender{
+		stream: stream,
+		abort:  abort,
+		window: opts.BatchWindow,
+		size:   opts.BatchSize,
+	}
+}
+
+func (s *streamSender) send(m *proto.ServerMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.window <= 0 {
+		s.write(m)
+		return
+	}
+	s.queue = append(s.queue, m)
+	if 0 < s.size && s.size <= len(s.queue) {
+		s.flushLocked()
+	} else if s.timer == nil {
+		s.timer = time.AfterFunc(s.window, s.flush)
+	}
+}
+
+// sendNow sends m right after the queued messages, e.g. for heartbeats which must not be delayed.
+func (s *streamSender) sendNow(m *proto.ServerMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flushLocked()
+	s.write(m)
+}
+
+func (s *streamSender) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flushLocked()
+}
+
+func (s *streamSender) flushLocked() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+
+	switch len(s.queue) {
+	case 0:
+		return
+	case 1:
+		s.write(s.queue[0])
+	default:
+		s.write(&proto.ServerMessage{
+			Event: &proto.ServerMessage_OnBatch{
+				OnBatch: &proto.ServerMessage_Batch{Messages: s.queue},
+			},
+		})
+	}
+	s.queue = nil
+}
+
+func (s *streamSender) write(m *proto.ServerMessage) {
+	if s.closed {
+		return
+	}
+	if err := s.stream.Send(m); err != nil {
+		s.abort(err)
+	}
+}
+
+// close drops the queued messages; the stream must not be used after Service returns.
+func (s *streamSender) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.queue = nil
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
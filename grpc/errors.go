@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"errors"
+	"strconv"
+
+	"quark/gameserver"
+	"quark/proto"
+)
+
+// rejectedError is an error of a message interceptor
+type rejectedError struct {
+	err error
+}
+
+func (e *rejectedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *rejectedError) Unwrap() error {
+	return e.err
+}
+
+var errorCodes = []struct {
+	err  error
+	code proto.ServerMessage_CommandError_Code
+}{
+	{gameserver.ErrRoomNotFound, proto.ServerMessage_CommandError_ROOM_NOT_FOUND},
+	{gameserver.ErrRoomFull, proto.ServerMessage_CommandError_ROOM_FULL},
+	{gameserver.ErrRoomClosed, proto.ServerMessage_CommandError_ROOM_CLOSED},
+	{gameserver.ErrNotMember, proto.ServerMessage_CommandError_NOT_MEMBER},
+	{gameserver.ErrAlreadyMember, proto.ServerMessage_CommandError_ALREADY_MEMBER},
+	{gameserver.ErrForbidden, proto.ServerMessage_CommandError_FORBIDDEN},
+	{gameserver.ErrInvalidPayload, proto.ServerMessage_CommandError_INVALID_PAYLOAD},
+	{gameserver.ErrTeamNotFound, proto.ServerMessage_CommandError_TEAM_NOT_FOUND},
+	{gameserver.ErrTeamFull, proto.ServerMessage_CommandError_TEAM_FULL},
+	{gameserver.ErrNotRoomMaster, proto.ServerMessage_CommandError_NOT_ROOM_MASTER},
+	{gameserver.ErrRequestTimeout, proto.ServerMessage_CommandError_REQUEST_TIMEOUT},
+	{gameserver.ErrRequestTargetNotFound, proto.ServerMessage_CommandError_REQUEST_TARGET_NOT_FOUND},
+	{gameserver.ErrRequestTargetLeft, proto.ServerMessage_CommandError_REQUEST_TARGET_LEFT},
+	{gameserver.ErrRequestDuplicated, proto.ServerMessage_CommandError_REQUEST_DUPLICATED},
+	{gameserver.ErrRequestNotHandled, proto.ServerMessage_CommandError_REQUEST_NOT_HANDLED},
+	{gameserver.ErrRequestNotPending, proto.ServerMessage_CommandError_REQUEST_NOT_PENDING},
+}
+
+func toErrorCode(err error) proto.ServerMessage_CommandError_Code {
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	var rejected *rejectedError
+	if errors.As(err, &rejected) {
+		return proto.ServerMessage_CommandError_REJECTED
+	}
+	return proto.ServerMessage_CommandError_UNKNOWN
+}
+
+func toErrorDetails(err error) *proto.ServerMessage_CommandError_Details {
+	var invalid *gameserver.PayloadError
+	if errors.As(err, &invalid) {
+		return &proto.ServerMessage_CommandError_Details{
+			Metadata: map[string]string{"code": strconv.FormatUint(uint64(invalid.Code), 10), "reason": invalid.Reason},
+		}
+	}
+	return nil
+}
+
+// toLegacyErrorCode returns the string code of errorCode, which older clients read instead of code
+func toLegacyErrorCode(c commandError) string {
+	var rejected *rejectedError
+	if errors.As(c.err, &rejected) {
+		if errors.Is(c.err, gameserver.ErrInvalidPayload) {
+			return "007"
+		}
+		return "006"
+	}
+	if c.response {
+		return "004"
+	}
+	switch c.cmd.(type) {
+	case *proto.ClientMessage_ChangeTeamCommand:
+		return "002"
+	case *proto.ClientMessage_SwapTeamsCommand:
+		return "003"
+	case *proto.ClientMessage_SendResponseCommand:
+		return "005"
+	default:
+		return "001"
+	}
+}
@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+)
+
+type heartbeat struct {
+	lastReceived time.Time
+	lastActive   time.Time
+
+	lastPingID uint64
+	lastPingAt time.Time
+
+	mu sync.Mutex
+}
+
+func newHeartbeat() *heartbeat {
+	now := time.Now()
+	return &heartbeat{lastReceived: now, lastActive: now}
+}
+
+// received records any message from the client
+func (h *heartbeat) received() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastReceived = time.Now()
+}
+
+// active records a command from the client other than heartbeats
+func (h *heartbeat) active() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	h.lastReceived = now
+	h.lastActive = now
+}
+
+func (h *heartbeat) ping() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastPingID += 1
+	h.lastPingAt = time.Now()
+	return h.lastPingID
+}
+
+// pong returns the round trip time if pingID is the latest ping
+func (h *heartbeat) pong(pingID uint64) (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if pingID != h.lastPingID || h.lastPingAt.IsZero() {
+		return 0, false
+	}
+	return time.Since(h.lastPingAt), true
+}
+
+func (h *heartbeat) sinceReceived() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Since(h.lastReceived)
+}
+
+func (h *heartbeat) sinceActive() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Since(h.lastActive)
+}
+
+func (h *heartbeat) sincePing() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.lastPingAt.IsZero() {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Since(h.lastPingAt)
+}
+
+func (o RoomServerOptions) heartbeatInterval() time.Duration {
+	interval := time.Duration(0)
+	for _, d := range []time.Duration{o.PingInterval, o.IdleTimeout / 4, o.AFKTimeout / 4} {
+		if 0 < d && (interval == 0 || d < interval) {
+			interval = d
+		}
+	}
+	if interval == 0 {
+		interval = time.Second
+	}
+	return interval
+}
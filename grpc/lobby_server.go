@@ -2,9 +2,11 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"quark"
 	"quark/masterserver"
@@ -12,73 +14,184 @@ import (
 	"quark/proto/primitive"
 )
 
+const (
+	defaultRoomListPageSize = 100
+	maxRoomListPageSize     = 1000
+)
+
+type LobbyServerOptions struct {
+	// StatsInterval is the interval of FleetStats sent to InLobby streams; 0 disables
+	StatsInterval time.Duration
+	// ReservationTTL releases the seats reserved on CreateRoom; masterserver.DefaultReservationTTL is used if 0
+	ReservationTTL time.Duration
+}
+
 type lobbyServer struct {
 	proto.UnimplementedLobbyServer
 
 	fleet *masterserver.Fleet
+	opts  LobbyServerOptions
+	stats *statsFeed
 }
 
-func NewLobbyServer(fleet *masterserver.Fleet) proto.LobbyServer {
-	return &lobbyServer{fleet: fleet}
+func NewLobbyServer(fleet *masterserver.Fleet, opts LobbyServerOptions) proto.LobbyServer {
+	s := &lobbyServer{fleet: fleet, opts: opts}
+	if 0 < opts.StatsInterval {
+		s.stats = newStatsFeed(fleet, opts.StatsInterval)
+	}
+	return s
 }
 
 func (s *lobbyServer) CreateRoom(ctx context.Context, req *proto.CreateRoomRequest) (*proto.CreateRoomResponse, error) {
-	var roomName string
+	opts := toRoomOptions(req.RoomOptions, s.opts.ReservationTTL)
 	if len(req.RoomName) == 0 {
-		roomName = "default"
-	} else {
-		roomName = req.RoomName
+		roomID := quark.NewRoomID()
+		if _, err := s.fleet.AllocateRoom(roomID, "", opts); err != nil {
+			return nil, status.Errorf(codes.Aborted, err.Error())
+		}
+		return &proto.CreateRoomResponse{RoomID: roomID.Uint64()}, nil
 	}
 
-	roomID := quark.NewRoomID()
-	_, err := s.fleet.AllocateRoom(roomID, roomName)
-	if err != nil && err != masterserver.ErrRoomAlreadyAllocated {
+	roomID, _, loaded, err := s.fleet.LoadOrAllocateRoom(req.RoomName, opts)
+	if err != nil {
 		return nil, status.Errorf(codes.Aborted, err.Error())
 	}
 	return &proto.CreateRoomResponse{
 		RoomID:       roomID.Uint64(),
-		AlreadyExist: err == masterserver.ErrRoomAlreadyAllocated,
+		AlreadyExist: loaded,
+	}, nil
+}
+
+func (s *lobbyServer) JoinOrCreateRoom(ctx context.Context, req *proto.JoinOrCreateRoomRequest) (*proto.JoinOrCreateRoomResponse, error) {
+	if len(req.RoomName) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "RoomName must not be empty")
+	}
+	roomID, addr, loaded, err := s.fleet.LoadOrAllocateRoom(req.RoomName, toRoomOptions(req.RoomOptions, s.opts.ReservationTTL))
+	if err != nil {
+		return nil, status.Errorf(codes.Aborted, err.Error())
+	}
+	return &proto.JoinOrCreateRoomResponse{
+		Server: &primitive.GameServer{
+			Address: addr.Addr,
+			Port:    addr.Port,
+		},
+		RoomID:  roomID.Uint64(),
+		Created: !loaded,
 	}, nil
 }
 
 func (s *lobbyServer) InLobby(req *proto.InLobbyRequest, stream proto.Lobby_InLobbyServer) error {
-	c := make(chan masterserver.RoomAllocatedEvent)
-	s.fleet.AddRoomAllocationListener(c)
+	pageSize := defaultRoomListPageSize
+	if req.PageSize != 0 {
+		pageSize = int(req.PageSize)
+	}
+	if maxRoomListPageSize < pageSize {
+		pageSize = maxRoomListPageSize
+	}
+	query, err := toRoomQuery(req.Lobby, req.Filter, req.SortBy, 0)
+	if err != nil {
+		return err
+	}
+
+	// visible rooms sent to the client
+	listed := make(map[quark.RoomID]bool)
+
+	rooms, c := s.fleet.SubscribeRoomList(req.Lobby)
 	defer func() {
-		s.fleet.RemoveRoomAllocationListener(c)
-		close(c)
+		s.fleet.UnsubscribeRoomList(c)
 	}()
+	if err := sendRoomListSnapshot(stream, query.Apply(rooms), pageSize, listed); err != nil {
+		return err
+	}
+
+	var stats chan *proto.FleetStats
+	if s.stats != nil {
+		stats = s.stats.subscribe()
+		defer s.stats.unsubscribe(stats)
+	}
 
 	for {
 		select {
 		case <-stream.Context().Done():
 			return stream.Context().Err()
-		case <-c:
-			rs := s.fleet.RoomList()
-
-			roomList := make([]*primitive.Room, len(rs))
-
-			for i, r := range rs {
-				roomList[i] = &primitive.Room{
-					RoomID:   r.RoomID.Uint64(),
-					RoomName: r.RoomName,
+		case m := <-stats:
+			err := stream.Send(&proto.InLobbyMessage{
+				Message: &proto.InLobbyMessage_OnStats{OnStats: m},
+			})
+			if err != nil {
+				return err
+			}
+		case ev, ok := <-c:
+			if !ok {
+				// fell behind the changes; start over with a new snapshot
+				rooms, c = s.fleet.SubscribeRoomList(req.Lobby)
+				listed = make(map[quark.RoomID]bool)
+				if err := sendRoomListSnapshot(stream, query.Apply(rooms), pageSize, listed); err != nil {
+					return err
 				}
+				continue
 			}
-			m := &proto.InLobbyMessage{
-				Message: &proto.InLobbyMessage_OnUpdatedRoomList{
-					OnUpdatedRoomList: &proto.InLobbyMessage_RoomListUpdatedEvent{
-						RoomList: roomList,
+
+			// rooms appear and disappear in the lobby as they start or stop matching
+			roomID := ev.Room.RoomID
+			visible := ev.Type != masterserver.RoomRemoved && query.Match(&ev.Room)
+			var m *proto.InLobbyMessage
+			switch {
+			case visible && !listed[roomID]:
+				listed[roomID] = true
+				m = &proto.InLobbyMessage{
+					Message: &proto.InLobbyMessage_OnRoomAdded{
+						OnRoomAdded: &proto.InLobbyMessage_RoomAdded{Room: toProtoRoom(ev.Room)},
 					},
-				},
+				}
+			case visible:
+				m = &proto.InLobbyMessage{
+					Message: &proto.InLobbyMessage_OnRoomUpdated{
+						OnRoomUpdated: &proto.InLobbyMessage_RoomUpdated{Room: toProtoRoom(ev.Room)},
+					},
+				}
+			case listed[roomID]:
+				delete(listed, roomID)
+				m = &proto.InLobbyMessage{
+					Message: &proto.InLobbyMessage_OnRoomRemoved{
+						OnRoomRemoved: &proto.InLobbyMessage_RoomRemoved{RoomID: roomID.Uint64()},
+					},
+				}
+			default:
+				continue
 			}
-			err := stream.Send(m)
-			if err != nil {
+			if err := stream.Send(m); err != nil {
 				return err
 			}
 		}
 	}
 }
 
+func (s *lobbyServer) ListRooms(ctx context.Context, req *proto.ListRoomsRequest) (*proto.ListRoomsResponse, error) {
+	limit := defaultRoomListPageSize
+	if req.Limit != 0 {
+		limit = int(req.Limit)
+	}
+	if maxRoomListPageSize < limit {
+		limit = maxRoomListPageSize
+	}
+	query, err := toRoomQuery(req.Lobby, req.Filter, req.SortBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms := s.fleet.QueryRooms(query)
+	roomList := make([]*primitive.Room, len(rooms))
+	for i, r := range rooms {
+		roomList[i] = toProtoRoom(r)
+	}
+	return &proto.ListRoomsResponse{RoomList: roomList}, nil
+}
+
+func (s *lobbyServer) GetStats(ctx context.Context, req *proto.GetStatsRequest) (*proto.FleetStats, error) {
+	return toProtoFleetStats(s.fleet.Stats()), nil
+}
+
 func (s *lobbyServer) JoinRoom(ctx context.Context, req *proto.JoinRoomRequest) (*proto.JoinRoomResponse, error) {
 	roomID := quark.RoomID(req.RoomID)
 	addr, ok := s.fleet.LookupGameServerAddr(roomID)
@@ -92,3 +205,152 @@ func (s *lobbyServer) JoinRoom(ctx context.Context, req *proto.JoinRoomRequest)
 		},
 	}, nil
 }
+
+func (s *lobbyServer) JoinRandomRoom(ctx context.Context, req *proto.JoinRandomRoomRequest) (*proto.JoinRandomRoomResponse, error) {
+	filter := masterserver.RoomFilter{
+		Lobby:      req.Lobby,
+		NamePrefix: req.RoomNamePrefix,
+		Properties: req.Properties,
+		MinActors:  uint(req.MinActors),
+		MaxActors:  uint(req.MaxActors),
+	}
+	room, addr, err := s.fleet.FindRandomRoom(filter)
+	if err == masterserver.ErrRoomNotFound {
+		return nil, status.Errorf(codes.NotFound, "no room matches")
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &proto.JoinRandomRoomResponse{
+		Server: &primitive.GameServer{
+			Address: addr.Addr,
+			Port:    addr.Port,
+		},
+		RoomID: room.RoomID.Uint64(),
+	}, nil
+}
+
+func toRoomOptions(o *proto.RoomOptions, reservationTTL time.Duration) masterserver.RoomOptions {
+	if o == nil {
+		return masterserver.RoomOptions{ReservationTTL: reservationTTL}
+	}
+	return masterserver.RoomOptions{
+		MaxActors:       uint(o.MaxActors),
+		Properties:      o.Properties,
+		LobbyProperties: o.LobbyProperties,
+		GameMode:        o.GameMode,
+		Region:          o.Region,
+		Hidden:          o.Hidden,
+		Locked:          o.Locked,
+		Lobby:           o.Lobby,
+		ReservedUserIDs: o.ReservedUserIDs,
+		ReservationTTL:  reservationTTL,
+		TeamCount:       uint(o.TeamCount),
+		UserTeams:       o.UserTeams,
+	}
+}
+
+// sendRoomListSnapshot sends the rooms in pages and adds them to listed;
+// an empty list is sent as one empty page
+func sendRoomListSnapshot(stream proto.Lobby_InLobbyServer, rooms []masterserver.RoomStatus, pageSize int, listed map[quark.RoomID]bool) error {
+	for _, r := range rooms {
+		listed[r.RoomID] = true
+	}
+
+	pageCount := (len(rooms) + pageSize - 1) / pageSize
+	if pageCount == 0 {
+		pageCount = 1
+	}
+	for page := 0; page < pageCount; page++ {
+		end := (page + 1) * pageSize
+		if len(rooms) < end {
+			end = len(rooms)
+		}
+		chunk := rooms[page*pageSize : end]
+
+		roomList := make([]*primitive.Room, len(chunk))
+		for i, r := range chunk {
+			roomList[i] = toProtoRoom(r)
+		}
+		err := stream.Send(&proto.InLobbyMessage{
+			Message: &proto.InLobbyMessage_OnRoomListSnapshot{
+				OnRoomListSnapshot: &proto.InLobbyMessage_RoomListSnapshot{
+					RoomList:  roomList,
+					Page:      uint32(page),
+					PageCount: uint32(pageCount),
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toProtoRoom(r masterserver.RoomStatus) *primitive.Room {
+	return &primitive.Room{
+		RoomID:     r.RoomID.Uint64(),
+		RoomName:   r.RoomName,
+		ActorCount: uint32(r.ActorCount),
+		MaxActors:  uint32(r.MaxActors),
+		Open:       r.Open,
+		Visible:    r.Visible,
+		Locked:     r.Locked,
+		GameMode:   r.GameMode,
+		Region:     r.Region,
+		CreatedAt:  timestamppb.New(r.CreatedAt),
+		Properties: r.Properties,
+		Lobby:      r.Lobby,
+
+		ReservedSeats: uint32(r.ReservedSeats(time.Now())),
+		BackfillSlots: uint32(r.BackfillSlots),
+	}
+}
+
+func toRoomQuery(lobby, filter string, sortBy []*proto.SortKey, limit int) (masterserver.RoomQuery, error) {
+	q := masterserver.RoomQuery{Lobby: lobby, Limit: limit}
+	if filter != "" {
+		expr, err := masterserver.ParseRoomExpr(filter)
+		if err != nil {
+			return q, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		q.Filter = expr
+	}
+	for _, k := range sortBy {
+		q.Sort = append(q.Sort, masterserver.SortKey{Field: k.Field, Descending: k.Descending})
+	}
+	return q, nil
+}
+
+func toProtoFleetStats(stats masterserver.FleetStats) *proto.FleetStats {
+	lobbies := make([]*proto.FleetStats_LobbyStats, len(stats.Lobbies))
+	for i, l := range stats.Lobbies {
+		lobbies[i] = &proto.FleetStats_LobbyStats{
+			Lobby:         l.Lobby,
+			Rooms:         uint32(l.Rooms),
+			ActorsInRooms: uint32(l.ActorsInRooms),
+			ActorsInLobby: uint32(l.ActorsInLobby),
+		}
+	}
+	gameServers := make([]*proto.FleetStats_GameServerStats, len(stats.GameServers))
+	for i, g := range stats.GameServers {
+		gameServers[i] = &proto.FleetStats_GameServerStats{
+			Server: &primitive.GameServer{
+				Address: g.Addr.Addr,
+				Port:    g.Addr.Port,
+				Region:  g.Region,
+			},
+			Rooms:        uint32(g.Rooms),
+			Actors:       uint32(g.Actors),
+			FreeCapacity: uint32(g.FreeCapacity),
+		}
+	}
+	return &proto.FleetStats{
+		Lobbies:          lobbies,
+		GameServers:      gameServers,
+		Rooms:            uint32(stats.Rooms),
+		ActorsInRooms:    uint32(stats.ActorsInRooms),
+		ActorsInLobbies:  uint32(stats.ActorsInLobbies),
+		FreeRoomCapacity: uint32(stats.FreeRoomCapacity),
+	}
+}
@@ -2,20 +2,27 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"quark"
 	"quark/masterserver"
 	"quark/proto"
-	"quark/proto/primitive"
 )
 
 const GameServerIDMetadataKey = "quark-gameserver-id"
 
+// listenerBufferSize is the number of fleet events a game server stream can
+// fall behind before the stream is ended
+const listenerBufferSize = 256
+
+var errGameServerBehind = status.Error(codes.Unavailable, "game server fell behind the fleet events")
+
 type masterServer struct {
 	proto.UnimplementedMasterServerServer
 
@@ -39,7 +46,8 @@ func (s *masterServer) RegisterGameServer(req *proto.RegisterGameServerRequest,
 	}
 
 	addr := masterserver.GameServerAddr{Addr: gs.Address, Port: gs.Port}
-	gameServerID := s.fleet.RegisterGameServer(masterserver.GameServerAddr{Addr: gs.Address, Port: gs.Port}, 5)
+	gameServerID := s.fleet.RegisterGameServerInRegion(addr, gs.Region, 5)
+	defer s.fleet.UnregisterGameServer(gameServerID)
 
 	err := stream.Send(&proto.MasterServerMessage{
 		Message: &proto.MasterServerMessage_Registered{
@@ -52,26 +60,50 @@ func (s *masterServer) RegisterGameServer(req *proto.RegisterGameServerRequest,
 		return err
 	}
 
-	c := make(chan masterserver.RoomAllocatedEvent)
+	c := make(chan masterserver.RoomAllocatedEvent, listenerBufferSize)
 	s.fleet.AddRoomAllocationListener(c)
-	defer func() {
-		s.fleet.RemoveRoomAllocationListener(c)
-		close(c)
-	}()
+	defer s.fleet.RemoveRoomAllocationListener(c)
+	reserved := make(chan masterserver.SeatsReservedEvent, listenerBufferSize)
+	s.fleet.AddSeatReservationListener(reserved)
+	defer s.fleet.RemoveSeatReservationListener(reserved)
 
 	for {
 		select {
 		case <-stream.Context().Done():
 			return stream.Context().Err()
-		case ev := <-c:
+		case ev, ok := <-c:
+			if !ok {
+				return errGameServerBehind
+			}
 			if addr == ev.GameServer {
 				m := &proto.MasterServerMessage{
 					Message: &proto.MasterServerMessage_Allocation{
 						Allocation: &proto.MasterServerMessage_RoomAllocation{
-							Room: &primitive.Room{
-								RoomID:   ev.Room.RoomID.Uint64(),
-								RoomName: ev.Room.RoomName,
-							},
+							Room:                 toProtoRoom(ev.Room),
+							ReservedUserIDs:      ev.Room.ReservedUserIDs,
+							ReservationTTLMillis: reservationTTLMillis(ev.Room.ReservationExpiresAt),
+							TeamCount:            uint32(ev.TeamCount),
+							UserTeams:            ev.UserTeams,
+						},
+					},
+				}
+				err := stream.Send(m)
+				if err != nil {
+					return err
+				}
+			}
+		case ev, ok := <-reserved:
+			if !ok {
+				return errGameServerBehind
+			}
+			if addr == ev.GameServer {
+				m := &proto.MasterServerMessage{
+					Message: &proto.MasterServerMessage_Reservation{
+						Reservation: &proto.MasterServerMessage_SeatReservation{
+							RoomID:    ev.RoomID.Uint64(),
+							UserIDs:   ev.UserIDs,
+							TtlMillis: reservationTTLMillis(ev.ExpiresAt),
+							UserTeams: ev.UserTeams,
 						},
 					},
 				}
@@ -102,10 +134,71 @@ func (s *masterServer) Update(stream proto.MasterServer_UpdateServer) error {
 			if err != nil {
 				return errors.WithStack(err)
 			}
+			// the rooms may have been closed in the meantime
 			for _, r := range m.UpdateRoomState {
-				newStatus := masterserver.RoomStatus{RoomID: quark.RoomID(r.Room.RoomID), RoomName: r.Room.RoomName, ActorCount: uint(r.ActorCount)}
+				roomID := quark.RoomID(r.GetRoom().GetRoomID())
+				if ok, err := s.ownsRoom(gameServerID, roomID); !ok {
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				newStatus := masterserver.RoomStatus{
+					RoomID:     roomID,
+					ActorCount: uint(r.ActorCount),
+
+					ReservedUserIDs: r.ReservedUserIDs,
+				}
 				err := s.fleet.UpdateRoomStatus(newStatus)
-				if err != nil {
+				if err != nil && err != masterserver.ErrRoomStatusNotFound {
+					return errors.WithStack(err)
+				}
+			}
+			for _, r := range m.SetRoomFlags {
+				roomID := quark.RoomID(r.RoomID)
+				if ok, err := s.ownsRoom(gameServerID, roomID); !ok {
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				err := s.fleet.SetRoomFlags(roomID, masterserver.RoomFlags{
+					Open:    toBoolPtr(r.Open),
+					Visible: toBoolPtr(r.Visible),
+					Locked:  toBoolPtr(r.Locked),
+				})
+				if err != nil && err != masterserver.ErrRoomStatusNotFound {
+					return errors.WithStack(err)
+				}
+			}
+			for _, id := range m.ClosedRoomIDs {
+				roomID := quark.RoomID(id)
+				if ok, err := s.ownsRoom(gameServerID, roomID); !ok {
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				err := s.fleet.RemoveRoom(roomID)
+				if err != nil && err != masterserver.ErrRoomStatusNotFound {
+					return errors.WithStack(err)
+				}
+			}
+			for _, b := range m.BackfillRequests {
+				roomID := quark.RoomID(b.RoomID)
+				if ok, err := s.ownsRoom(gameServerID, roomID); !ok {
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				err := s.fleet.RequestBackfill(masterserver.Backfill{
+					RoomID:      roomID,
+					Slots:       uint(b.Slots),
+					Team:        b.Team,
+					SkillRating: b.SkillRating,
+				})
+				if err != nil && err != masterserver.ErrRoomStatusNotFound {
 					return errors.WithStack(err)
 				}
 			}
@@ -113,6 +206,35 @@ func (s *masterServer) Update(stream proto.MasterServer_UpdateServer) error {
 	}
 }
 
+// ownsRoom reports whether the room is allocated on the game server. A room
+// which is gone is skipped; a room of another game server is an error.
+func (s *masterServer) ownsRoom(gameServerID masterserver.GameServerID, roomID quark.RoomID) (bool, error) {
+	id, ok := s.fleet.LookupGameServerID(roomID)
+	if !ok {
+		return false, nil
+	}
+	if id != gameServerID {
+		return false, status.Errorf(codes.PermissionDenied, "room %d is not on the game server", roomID)
+	}
+	return true, nil
+}
+
+// reservationTTLMillis returns the time left until the reserved seats expire
+func reservationTTLMillis(expiresAt time.Time) uint32 {
+	if d := time.Until(expiresAt); 0 < d {
+		return uint32(d.Milliseconds())
+	}
+	return 0
+}
+
+func toBoolPtr(v *wrapperspb.BoolValue) *bool {
+	if v == nil {
+		return nil
+	}
+	b := v.Value
+	return &b
+}
+
 func getGameServerID(ctx context.Context) (masterserver.GameServerID, bool) {
 	m, ok := metadata.FromIncomingContext(ctx)
 	if !ok || len(m[GameServerIDMetadataKey]) == 0 {
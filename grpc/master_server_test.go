@@ -2,14 +2,21 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	"quark"
 	"quark/masterserver"
 	"quark/proto"
 	"quark/proto/primitive"
@@ -32,7 +39,7 @@ func TestMasterServer(t *testing.T) {
 	}
 	var lobby proto.LobbyClient
 	{
-		lobbyServer := NewLobbyServer(fleet)
+		lobbyServer := NewLobbyServer(fleet, LobbyServerOptions{})
 		lis := listenLobbyServer(ctx, lobbyServer)
 
 		conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
@@ -100,3 +107,555 @@ func listenLobbyServer(ctx context.Context, svr proto.LobbyServer) *bufconn.List
 	}()
 	return lis
 }
+
+func TestMasterServer_ReservedSeats(t *testing.T) {
+	fleet := masterserver.NewFleet()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	var ms proto.MasterServerClient
+	{
+		lis := listenMasterServer(ctx, NewMasterServer(fleet))
+		conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+		require.NoError(t, err)
+		ms = proto.NewMasterServerClient(conn)
+	}
+	var lobby proto.LobbyClient
+	{
+		lis := listenLobbyServer(ctx, NewLobbyServer(fleet, LobbyServerOptions{}))
+		conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+		require.NoError(t, err)
+		lobby = proto.NewLobbyClient(conn)
+	}
+
+	gsStream, err := ms.RegisterGameServer(ctx, &proto.RegisterGameServerRequest{
+		NewGameServer: &primitive.GameServer{Address: "0.0.0.0", Port: "14000"},
+	})
+	require.NoError(t, err)
+	_, err = gsStream.Recv()
+	require.NoError(t, err)
+
+	resp, err := lobby.CreateRoom(ctx, &proto.CreateRoomRequest{
+		RoomName:    "test",
+		RoomOptions: &proto.RoomOptions{ReservedUserIDs: []string{"alice"}},
+	})
+	require.NoError(t, err)
+
+	m, err := gsStream.Recv()
+	require.NoError(t, err)
+	allocation := m.GetAllocation()
+	require.NotNil(t, allocation)
+	assert.Equal(t, resp.RoomID, allocation.Room.RoomID)
+	assert.Equal(t, []string{"alice"}, allocation.ReservedUserIDs)
+	assert.Equal(t, uint32(1), allocation.Room.ReservedSeats)
+	assert.NotZero(t, allocation.ReservationTTLMillis)
+}
+
+func TestMasterServer_SetRoomFlags(t *testing.T) {
+	fleet := masterserver.NewFleet()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenMasterServer(ctx, NewMasterServer(fleet))
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	ms := proto.NewMasterServerClient(conn)
+
+	gsStream, err := ms.RegisterGameServer(ctx, &proto.RegisterGameServerRequest{
+		NewGameServer: &primitive.GameServer{Address: "0.0.0.0", Port: "14000"},
+	})
+	require.NoError(t, err)
+	m, err := gsStream.Recv()
+	require.NoError(t, err)
+	gameServerID := m.GetRegistered().GameServerID
+
+	roomID := quark.NewRoomID()
+	go func() {
+		_, err := fleet.AllocateRoom(roomID, "", masterserver.RoomOptions{})
+		assert.NoError(t, err)
+	}()
+	_, err = gsStream.Recv()
+	require.NoError(t, err)
+
+	roomOf := func() masterserver.RoomStatus {
+		for _, r := range fleet.RoomList() {
+			if r.RoomID == roomID {
+				return r
+			}
+		}
+		return masterserver.RoomStatus{}
+	}
+
+	update, err := ms.Update(metadata.AppendToOutgoingContext(ctx, GameServerIDMetadataKey, gameServerID))
+	require.NoError(t, err)
+
+	// a status with only the actor count keeps the flags
+	require.NoError(t, update.Send(&proto.GameServerStatus{
+		UpdateRoomState: []*proto.GameServerStatus_RoomState{
+			{Room: &primitive.Room{RoomID: roomID.Uint64()}, ActorCount: 2},
+		},
+	}))
+	assert.Eventually(t, func() bool { return roomOf().ActorCount == 2 }, time.Second, 10*time.Millisecond)
+	assert.True(t, roomOf().Open)
+	assert.True(t, roomOf().Visible)
+
+	require.NoError(t, update.Send(&proto.GameServerStatus{
+		SetRoomFlags: []*proto.GameServerStatus_SetRoomFlags{
+			{RoomID: roomID.Uint64(), Open: wrapperspb.Bool(false)},
+		},
+	}))
+	assert.Eventually(t, func() bool { return !roomOf().Open }, time.Second, 10*time.Millisecond)
+	assert.True(t, roomOf().Visible)
+	assert.False(t, roomOf().Locked)
+}
+
+func TestMasterServer_UpdateOwnRooms(t *testing.T) {
+	fleet := masterserver.NewFleet()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenMasterServer(ctx, NewMasterServer(fleet))
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	ms := proto.NewMasterServerClient(conn)
+
+	gsStream, err := ms.RegisterGameServer(ctx, &proto.RegisterGameServerRequest{
+		NewGameServer: &primitive.GameServer{Address: "0.0.0.0", Port: "14000"},
+	})
+	require.NoError(t, err)
+	m, err := gsStream.Recv()
+	require.NoError(t, err)
+	gameServerID := m.GetRegistered().GameServerID
+
+	roomID := quark.NewRoomID()
+	go func() {
+		_, err := fleet.AllocateRoom(roomID, "", masterserver.RoomOptions{})
+		assert.NoError(t, err)
+	}()
+	_, err = gsStream.Recv()
+	require.NoError(t, err)
+	other := fleet.RegisterGameServer(masterserver.GameServerAddr{Addr: "0.0.0.0", Port: "14001"}, 10)
+
+	// rooms which are gone, or without an ID, are skipped
+	update, err := ms.Update(metadata.AppendToOutgoingContext(ctx, GameServerIDMetadataKey, gameServerID))
+	require.NoError(t, err)
+	require.NoError(t, update.Send(&proto.GameServerStatus{
+		UpdateRoomState: []*proto.GameServerStatus_RoomState{
+			{ActorCount: 1},
+			{Room: &primitive.Room{RoomID: quark.NewRoomID().Uint64()}, ActorCount: 1},
+			{Room: &primitive.Room{RoomID: roomID.Uint64()}, ActorCount: 2},
+		},
+	}))
+	assert.Eventually(t, func() bool {
+		rooms := fleet.RoomList()
+		return len(rooms) == 1 && rooms[0].ActorCount == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// another game server can not close the room
+	update, err = ms.Update(metadata.AppendToOutgoingContext(ctx, GameServerIDMetadataKey, string(other)))
+	require.NoError(t, err)
+	require.NoError(t, update.Send(&proto.GameServerStatus{ClosedRoomIDs: []uint64{roomID.Uint64()}}))
+	_, err = update.CloseAndRecv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Len(t, fleet.RoomList(), 1)
+}
+
+func TestMasterServer_JoinOrCreateRoom(t *testing.T) {
+	fleet := masterserver.NewFleet()
+	fleet.RegisterGameServer(masterserver.GameServerAddr{Addr: "0.0.0.0", Port: "14000"}, 100)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenLobbyServer(ctx, NewLobbyServer(fleet, LobbyServerOptions{}))
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	lobby := proto.NewLobbyClient(conn)
+
+	created, err := lobby.JoinOrCreateRoom(ctx, &proto.JoinOrCreateRoomRequest{RoomName: "test"})
+	require.NoError(t, err)
+	assert.True(t, created.Created)
+
+	again, err := lobby.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: "test"})
+	require.NoError(t, err)
+	assert.True(t, again.AlreadyExist)
+	assert.Equal(t, created.RoomID, again.RoomID)
+
+	joined, err := lobby.JoinOrCreateRoom(ctx, &proto.JoinOrCreateRoomRequest{RoomName: "test"})
+	require.NoError(t, err)
+	assert.False(t, joined.Created)
+	assert.Equal(t, created.RoomID, joined.RoomID)
+	assert.Equal(t, "14000", joined.Server.Port)
+}
+
+func TestMasterServer_JoinRandomRoom(t *testing.T) {
+	fleet := masterserver.NewFleet()
+	fleet.RegisterGameServer(masterserver.GameServerAddr{Addr: "0.0.0.0", Port: "14000"}, 100)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenLobbyServer(ctx, NewLobbyServer(fleet, LobbyServerOptions{}))
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	lobby := proto.NewLobbyClient(conn)
+
+	resp, err := lobby.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: "test"})
+	require.NoError(t, err)
+
+	_, err = lobby.JoinRandomRoom(ctx, &proto.JoinRandomRoomRequest{RoomNamePrefix: "other"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	random, err := lobby.JoinRandomRoom(ctx, &proto.JoinRandomRoomRequest{RoomNamePrefix: "te"})
+	require.NoError(t, err)
+	assert.Equal(t, resp.RoomID, random.RoomID)
+	assert.Equal(t, "0.0.0.0", random.Server.Address)
+	assert.Equal(t, "14000", random.Server.Port)
+}
+
+func TestLobbyServer_InLobby(t *testing.T) {
+	fleet := masterserver.NewFleet()
+	fleet.RegisterGameServer(masterserver.GameServerAddr{Addr: "0.0.0.0", Port: "14000"}, 100)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenLobbyServer(ctx, NewLobbyServer(fleet, LobbyServerOptions{}))
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	lobby := proto.NewLobbyClient(conn)
+
+	for i := 0; i < 5; i++ {
+		_, err := lobby.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: fmt.Sprintf("room-%d", i)})
+		require.NoError(t, err)
+	}
+
+	_, err = lobby.CreateRoom(ctx, &proto.CreateRoomRequest{
+		RoomName:    "hidden",
+		RoomOptions: &proto.RoomOptions{Hidden: true},
+	})
+	require.NoError(t, err)
+
+	stream, err := lobby.InLobby(ctx, &proto.InLobbyRequest{PageSize: 2})
+	require.NoError(t, err)
+
+	var rooms []*primitive.Room
+	for i := 0; i < 3; i++ {
+		m, err := stream.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Message, &proto.InLobbyMessage_OnRoomListSnapshot{})
+		snapshot := m.GetOnRoomListSnapshot()
+		assert.Equal(t, uint32(i), snapshot.Page)
+		assert.Equal(t, uint32(3), snapshot.PageCount)
+		rooms = append(rooms, snapshot.RoomList...)
+	}
+	assert.Len(t, rooms, 5)
+
+	resp, err := lobby.CreateRoom(ctx, &proto.CreateRoomRequest{
+		RoomName: "new",
+		RoomOptions: &proto.RoomOptions{
+			MaxActors:       4,
+			GameMode:        "ctf",
+			Properties:      map[string]string{"map": "forest", "secret": "x"},
+			LobbyProperties: []string{"map"},
+		},
+	})
+	require.NoError(t, err)
+	m, err := stream.Recv()
+	require.NoError(t, err)
+	require.IsType(t, m.Message, &proto.InLobbyMessage_OnRoomAdded{})
+	assert.Equal(t, resp.RoomID, m.GetOnRoomAdded().Room.RoomID)
+	added := m.GetOnRoomAdded().Room
+	assert.Equal(t, "new", added.RoomName)
+	assert.Equal(t, uint32(4), added.MaxActors)
+	assert.Equal(t, "ctf", added.GameMode)
+	assert.True(t, added.Open)
+	assert.True(t, added.Visible)
+	assert.NotZero(t, added.CreatedAt.AsTime())
+	assert.Equal(t, map[string]string{"map": "forest"}, added.Properties)
+
+	roomID := quark.RoomID(resp.RoomID)
+	require.NoError(t, fleet.UpdateRoomStatus(masterserver.RoomStatus{RoomID: roomID, ActorCount: 2}))
+	m, err = stream.Recv()
+	require.NoError(t, err)
+	require.IsType(t, m.Message, &proto.InLobbyMessage_OnRoomUpdated{})
+	assert.Equal(t, uint32(2), m.GetOnRoomUpdated().Room.ActorCount)
+
+	// invisible rooms are removed from the lobby
+
+	hidden := false
+	require.NoError(t, fleet.SetRoomFlags(roomID, masterserver.RoomFlags{Visible: &hidden}))
+	m, err = stream.Recv()
+	require.NoError(t, err)
+	require.IsType(t, m.Message, &proto.InLobbyMessage_OnRoomRemoved{})
+	assert.Equal(t, resp.RoomID, m.GetOnRoomRemoved().RoomID)
+}
+
+func TestLobbyServer_ListRooms(t *testing.T) {
+	fleet := masterserver.NewFleet()
+	fleet.RegisterGameServerInRegion(masterserver.GameServerAddr{Addr: "0.0.0.0", Port: "14000"}, "ap-northeast", 100)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenLobbyServer(ctx, NewLobbyServer(fleet, LobbyServerOptions{}))
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	lobby := proto.NewLobbyClient(conn)
+
+	for i, mode := range []string{"ctf", "dm", "ctf", "ctf"} {
+		_, err := lobby.CreateRoom(ctx, &proto.CreateRoomRequest{
+			RoomName:    fmt.Sprintf("room-%d", i),
+			RoomOptions: &proto.RoomOptions{GameMode: mode, MaxActors: uint32(i + 1)},
+		})
+		require.NoError(t, err)
+	}
+
+	resp, err := lobby.ListRooms(ctx, &proto.ListRoomsRequest{
+		Filter: `mode == "ctf" && actors < max && region == "ap-northeast"`,
+		SortBy: []*proto.SortKey{{Field: "max", Descending: true}},
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.RoomList, 2)
+	assert.Equal(t, "room-3", resp.RoomList[0].RoomName)
+	assert.Equal(t, "room-2", resp.RoomList[1].RoomName)
+
+	_, err = lobby.CreateRoom(ctx, &proto.CreateRoomRequest{
+		RoomName:    "ranked",
+		RoomOptions: &proto.RoomOptions{GameMode: "dm", Lobby: "ranked"},
+	})
+	require.NoError(t, err)
+	resp, err = lobby.ListRooms(ctx, &proto.ListRoomsRequest{Lobby: "ranked"})
+	require.NoError(t, err)
+	require.Len(t, resp.RoomList, 1)
+	assert.Equal(t, "ranked", resp.RoomList[0].Lobby)
+
+	_, err = lobby.ListRooms(ctx, &proto.ListRoomsRequest{Filter: `mode ==`})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream, err := lobby.InLobby(ctx, &proto.InLobbyRequest{Filter: `mode == "dm"`})
+	require.NoError(t, err)
+	m, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, m.GetOnRoomListSnapshot().RoomList, 1)
+	assert.Equal(t, "room-1", m.GetOnRoomListSnapshot().RoomList[0].RoomName)
+}
+
+func TestLobbyServer_Stats(t *testing.T) {
+	fleet := masterserver.NewFleet()
+	fleet.RegisterGameServer(masterserver.GameServerAddr{Addr: "0.0.0.0", Port: "14000"}, 10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenLobbyServer(ctx, NewLobbyServer(fleet, LobbyServerOptions{StatsInterval: 20 * time.Millisecond}))
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	lobby := proto.NewLobbyClient(conn)
+
+	resp, err := lobby.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: "room"})
+	require.NoError(t, err)
+	require.NoError(t, fleet.UpdateRoomStatus(masterserver.RoomStatus{RoomID: quark.RoomID(resp.RoomID), ActorCount: 3}))
+
+	stream, err := lobby.InLobby(ctx, &proto.InLobbyRequest{})
+	require.NoError(t, err)
+	m, err := stream.Recv()
+	require.NoError(t, err)
+	require.IsType(t, m.Message, &proto.InLobbyMessage_OnRoomListSnapshot{})
+
+	for i := 0; i < 2; i++ {
+		m, err = stream.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Message, &proto.InLobbyMessage_OnStats{})
+		stats := m.GetOnStats()
+		assert.Equal(t, uint32(1), stats.Rooms)
+		assert.Equal(t, uint32(3), stats.ActorsInRooms)
+		assert.Equal(t, uint32(1), stats.ActorsInLobbies)
+		assert.Equal(t, uint32(9), stats.FreeRoomCapacity)
+		require.Len(t, stats.GameServers, 1)
+		assert.Equal(t, "14000", stats.GameServers[0].Server.Port)
+	}
+
+	stats, err := lobby.GetStats(ctx, &proto.GetStatsRequest{})
+	require.NoError(t, err)
+	require.Len(t, stats.Lobbies, 1)
+	assert.Equal(t, uint32(3), stats.Lobbies[0].ActorsInRooms)
+	assert.Equal(t, uint32(1), stats.Lobbies[0].ActorsInLobby)
+}
+
+func TestLobbyServer_StatsFeed(t *testing.T) {
+	fleet := masterserver.NewFleet()
+	fleet.RegisterGameServer(masterserver.GameServerAddr{Addr: "0.0.0.0", Port: "14000"}, 10)
+
+	feed := newStatsFeed(fleet, 50*time.Millisecond)
+	c1 := feed.subscribe()
+	c2 := feed.subscribe()
+
+	// every stream gets the same snapshot of an interval
+	for i := 0; i < 2; i++ {
+		assert.Same(t, <-c1, <-c2)
+	}
+
+	feed.unsubscribe(c1)
+	feed.unsubscribe(c2)
+	assert.Nil(t, feed.last)
+
+	c3 := feed.subscribe()
+	defer feed.unsubscribe(c3)
+	assert.Equal(t, uint32(10), (<-c3).FreeRoomCapacity)
+}
+
+func listenMatchmakerServer(ctx context.Context, svr proto.MatchmakerServer) *bufconn.Listener {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	proto.RegisterMatchmakerServer(s, svr)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("Server exited with error: %v", err)
+		}
+	}()
+	return lis
+}
+
+func TestMatchmakerServer_FindMatch(t *testing.T) {
+	fleet := masterserver.NewFleet()
+	fleet.RegisterGameServer(masterserver.GameServerAddr{Addr: "0.0.0.0", Port: "14000"}, 10)
+
+	mm, err := masterserver.NewMatchmaker(fleet, masterserver.MatchmakerOptions{
+		Rules: map[string]masterserver.MatchRules{
+			"2v2": {PlayersPerMatch: 4, TeamCount: 2, SkillWindow: 100},
+		},
+		Interval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer mm.Stop()
+
+	ctx := context.Background()
+	lis := listenMatchmakerServer(ctx, NewMatchmakerServer(mm))
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	client := proto.NewMatchmakerClient(conn)
+
+	{
+		stream, err := client.FindMatch(ctx, &proto.FindMatchRequest{UserIDs: []string{"a"}, Mode: "1v1"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	findMatch := func(userIDs ...string) proto.Matchmaker_FindMatchClient {
+		stream, err := client.FindMatch(ctx, &proto.FindMatchRequest{UserIDs: userIDs, Mode: "2v2", SkillRating: 1000})
+		require.NoError(t, err)
+
+		m, err := stream.Recv()
+		require.NoError(t, err)
+		assert.NotEmpty(t, m.GetOnQueued().TicketID)
+		return stream
+	}
+	party := findMatch("a", "b")
+	solo1 := findMatch("c")
+	solo2 := findMatch("d")
+
+	m, err := party.Recv()
+	require.NoError(t, err)
+	found := m.GetOnMatchFound()
+	require.NotNil(t, found)
+	assert.Equal(t, "14000", found.Server.Port)
+	require.Len(t, found.Seats, 2)
+	assert.Equal(t, found.Seats[0].Team, found.Seats[1].Team)
+	assert.NotZero(t, found.Seats[0].Team)
+
+	for _, s := range []proto.Matchmaker_FindMatchClient{solo1, solo2} {
+		m, err := s.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, found.RoomID, m.GetOnMatchFound().RoomID)
+		require.Len(t, m.GetOnMatchFound().Seats, 1)
+		assert.NotEqual(t, found.Seats[0].Team, m.GetOnMatchFound().Seats[0].Team)
+	}
+
+	addr, ok := fleet.LookupGameServerAddr(quark.RoomID(found.RoomID))
+	assert.True(t, ok)
+	assert.Equal(t, "14000", addr.Port)
+}
+
+func TestMasterServer_Backfill(t *testing.T) {
+	fleet := masterserver.NewFleet()
+	mm, err := masterserver.NewMatchmaker(fleet, masterserver.MatchmakerOptions{
+		Rules: map[string]masterserver.MatchRules{
+			"2v2": {PlayersPerMatch: 4, TeamCount: 2, SkillWindow: 100},
+		},
+		Interval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer mm.Stop()
+
+	ctx := context.Background()
+	var ms proto.MasterServerClient
+	{
+		lis := listenMasterServer(ctx, NewMasterServer(fleet))
+		conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+		require.NoError(t, err)
+		ms = proto.NewMasterServerClient(conn)
+	}
+	var client proto.MatchmakerClient
+	{
+		lis := listenMatchmakerServer(ctx, NewMatchmakerServer(mm))
+		conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+		require.NoError(t, err)
+		client = proto.NewMatchmakerClient(conn)
+	}
+
+	gsStream, err := ms.RegisterGameServer(ctx, &proto.RegisterGameServerRequest{
+		NewGameServer: &primitive.GameServer{Address: "0.0.0.0", Port: "14000"},
+	})
+	require.NoError(t, err)
+	m, err := gsStream.Recv()
+	require.NoError(t, err)
+	gameServerID := m.GetRegistered().GameServerID
+
+	roomID := quark.NewRoomID()
+	go func() {
+		_, err := fleet.AllocateRoom(roomID, "", masterserver.RoomOptions{MaxActors: 4, GameMode: "2v2", Hidden: true})
+		assert.NoError(t, err)
+	}()
+	m, err = gsStream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, roomID.Uint64(), m.GetAllocation().Room.RoomID)
+
+	update, err := ms.Update(metadata.AppendToOutgoingContext(ctx, GameServerIDMetadataKey, gameServerID))
+	require.NoError(t, err)
+	require.NoError(t, update.Send(&proto.GameServerStatus{
+		BackfillRequests: []*proto.GameServerStatus_BackfillRequest{
+			{RoomID: roomID.Uint64(), Slots: 1, Team: 2, SkillRating: 1000},
+		},
+	}))
+
+	stream, err := client.FindMatch(ctx, &proto.FindMatchRequest{UserIDs: []string{"alice"}, Mode: "2v2", SkillRating: 1050})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	m, err = gsStream.Recv()
+	require.NoError(t, err)
+	reservation := m.GetReservation()
+	require.NotNil(t, reservation)
+	assert.Equal(t, roomID.Uint64(), reservation.RoomID)
+	assert.Equal(t, []string{"alice"}, reservation.UserIDs)
+	assert.Equal(t, map[string]uint32{"alice": 2}, reservation.UserTeams)
+	assert.NotZero(t, reservation.TtlMillis)
+
+	ev, err := stream.Recv()
+	require.NoError(t, err)
+	found := ev.GetOnMatchFound()
+	require.NotNil(t, found)
+	assert.True(t, found.Backfill)
+	assert.Equal(t, roomID.Uint64(), found.RoomID)
+	require.Len(t, found.Seats, 1)
+	assert.Equal(t, "alice", found.Seats[0].UserID)
+	assert.Equal(t, uint32(2), found.Seats[0].Team)
+	assert.Empty(t, fleet.Backfills())
+}
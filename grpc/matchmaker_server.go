@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"quark/masterserver"
+	"quark/proto"
+	"quark/proto/primitive"
+)
+
+type matchmakerServer struct {
+	proto.UnimplementedMatchmakerServer
+
+	mm *masterserver.Matchmaker
+}
+
+func NewMatchmakerServer(mm *masterserver.Matchmaker) proto.MatchmakerServer {
+	return &matchmakerServer{mm: mm}
+}
+
+func (s *matchmakerServer) FindMatch(req *proto.FindMatchRequest, stream proto.Matchmaker_FindMatchServer) error {
+	id, c, err := s.mm.Enqueue(masterserver.Ticket{
+		UserIDs:       req.UserIDs,
+		Mode:          req.Mode,
+		SkillRating:   req.SkillRating,
+		Region:        req.Region,
+		LatencyMillis: req.LatencyMillis,
+	})
+	switch err {
+	case nil:
+	case masterserver.ErrUnknownMode, masterserver.ErrInvalidTicket:
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	default:
+		return status.Errorf(codes.Unavailable, err.Error())
+	}
+
+	if err := stream.Send(&proto.MatchmakingEvent{
+		Message: &proto.MatchmakingEvent_OnQueued{
+			OnQueued: &proto.MatchmakingEvent_Queued{TicketID: string(id)},
+		},
+	}); err != nil {
+		_ = s.mm.Cancel(id)
+		return err
+	}
+
+	select {
+	case <-stream.Context().Done():
+		_ = s.mm.Cancel(id)
+		return stream.Context().Err()
+	case match, ok := <-c:
+		if !ok {
+			return status.Errorf(codes.Unavailable, masterserver.ErrMatchmakerStopped.Error())
+		}
+		return stream.Send(&proto.MatchmakingEvent{
+			Message: &proto.MatchmakingEvent_OnMatchFound{
+				OnMatchFound: toProtoMatchFound(&match, req.UserIDs),
+			},
+		})
+	}
+}
+
+// toProtoMatchFound returns the match with the seats of the users
+func toProtoMatchFound(match *masterserver.Match, userIDs []string) *proto.MatchmakingEvent_MatchFound {
+	users := make(map[string]bool, len(userIDs))
+	for _, u := range userIDs {
+		users[u] = true
+	}
+	seats := make([]*proto.Seat, 0, len(userIDs))
+	for _, seat := range match.Seats {
+		if users[seat.UserID] {
+			seats = append(seats, &proto.Seat{UserID: seat.UserID, Team: seat.Team})
+		}
+	}
+	return &proto.MatchmakingEvent_MatchFound{
+		Server: &primitive.GameServer{
+			Address: match.GameServer.Addr,
+			Port:    match.GameServer.Port,
+		},
+		RoomID:   match.RoomID.Uint64(),
+		Region:   match.Region,
+		Seats:    seats,
+		Backfill: match.Backfill,
+	}
+}
@@ -3,26 +3,79 @@ package grpc
 import (
 	"context"
 	"io"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"quark"
 	"quark/gameserver"
 	"quark/proto"
 )
 
+// UserIDMetadataKey identifies the user of a Service stream, e.g. set by an authenticating proxy.
+const UserIDMetadataKey = "quark-user-id"
+
 type roomServer struct {
 	proto.UnimplementedRoomServer
 
 	roomSet *gameserver.RoomSet
+	opts    RoomServerOptions
+}
+
+// RoomServerOptions configures the heartbeat and batching of Service streams.
+// Zero values disable the corresponding feature.
+type RoomServerOptions struct {
+	// PingInterval is the interval of Ping messages sent to clients
+	PingInterval time.Duration
+	// IdleTimeout removes actors which have sent nothing, including Pong, for the duration
+	IdleTimeout time.Duration
+	// AFKTimeout removes actors which have sent no commands except heartbeats for the duration
+	AFKTimeout time.Duration
+
+	// BatchWindow queues outbound messages for the duration and sends them as one Batch message
+	BatchWindow time.Duration
+	// BatchSize sends the batch before the window ends once it holds this many messages
+	BatchSize int
+
+	// Interceptors are the message interceptors of rooms by the room type given on creation
+	Interceptors map[string][]gameserver.MessageInterceptor
+	// Schemas validate message payloads of rooms by the room type, before the interceptors
+	Schemas map[string]*gameserver.SchemaRegistry
+
+	// ReservationTTL releases the seats reserved on CreateRoom; gameserver.DefaultReservationTTL is used if 0
+	ReservationTTL time.Duration
 }
 
-func NewRoomServer() proto.RoomServer {
+func NewRoomServer(roomSet *gameserver.RoomSet, opts RoomServerOptions) proto.RoomServer {
 	return &roomServer{
-		roomSet: gameserver.NewRoomSet(),
+		roomSet: roomSet,
+		opts:    opts,
 	}
 }
 
 func (s *roomServer) CreateRoom(ctx context.Context, req *proto.CreateRoomRequest) (*proto.CreateRoomResponse, error) {
-	roomID, loaded := s.roomSet.NewRoom(req.RoomName)
+	var opts gameserver.RoomOptions
+	if req.RoomOptions != nil {
+		opts.TeamCount = uint(req.RoomOptions.TeamCount)
+		opts.TeamSize = uint(req.RoomOptions.TeamSize)
+		opts.MaxActors = uint(req.RoomOptions.MaxActors)
+		opts.ReservedUserIDs = req.RoomOptions.ReservedUserIDs
+		opts.ReservationTTL = s.opts.ReservationTTL
+		if len(req.RoomOptions.UserTeams) != 0 {
+			opts.UserTeams = make(map[string]gameserver.TeamID, len(req.RoomOptions.UserTeams))
+			for u, team := range req.RoomOptions.UserTeams {
+				opts.UserTeams[u] = gameserver.TeamID(team)
+			}
+		}
+		opts.Interceptors = s.opts.Interceptors[req.RoomOptions.RoomType]
+		if schemas, ok := s.opts.Schemas[req.RoomOptions.RoomType]; ok {
+			opts.Interceptors = append([]gameserver.MessageInterceptor{schemas.Interceptor()}, opts.Interceptors...)
+		}
+	}
+	roomID, loaded := s.roomSet.NewRoom(req.RoomName, opts)
 	return &proto.CreateRoomResponse{
 		RoomID:       roomID.Uint64(),
 		AlreadyExist: loaded,
@@ -31,11 +84,37 @@ func (s *roomServer) CreateRoom(ctx context.Context, req *proto.CreateRoomReques
 
 func (s *roomServer) Service(stream proto.Room_ServiceServer) error {
 	fail := make(chan error, 1)
+	abort := func(err error) {
+		select {
+		case fail <- err:
+		default:
+		}
+	}
+
+	sender := newStreamSender(stream, s.opts, abort)
+	defer sender.close()
+	send := sender.send
 
-	onJoined := make(chan interface{})
-	onLeaved := make(chan interface{})
+	onJoined := make(chan commandResult)
+	onLeaved := make(chan commandResult)
 
-	actor := gameserver.NewActor()
+	actor := newActor(stream.Context())
+	defer actor.LeaveAll(context.Background(), gameserver.LeaveReasonDisconnected, "")
+
+	hb := newHeartbeat()
+	ctx := stream.Context()
+
+	// messages from all rooms the actor is in
+	inbox := make(chan roomMessage, 16)
+	forward := func(roomID quark.RoomID, c <-chan gameserver.Message) {
+		for m := range c {
+			select {
+			case inbox <- roomMessage{roomID: roomID, message: m}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
 
 	// recv loop
 	go func() {
@@ -45,41 +124,100 @@ func (s *roomServer) Service(stream proto.Room_ServiceServer) error {
 		for {
 			select {
 			case <-stream.Context().Done():
-				actor.Leave()
+				actor.LeaveAll(context.Background(), gameserver.LeaveReasonDisconnected, stream.Context().Err().Error())
 				return
 			default:
 				in, err := stream.Recv()
 				if err == io.EOF {
 					return
 				} else if err != nil {
-					fail <- err
+					actor.LeaveAll(context.Background(), gameserver.LeaveReasonDisconnected, err.Error())
+					abort(err)
 					return
 				}
 
+				switch cmd := in.Command.(type) {
+				case *proto.ClientMessage_Ping:
+					hb.received()
+					sender.sendNow(&proto.ServerMessage{
+						Event: &proto.ServerMessage_OnPong{
+							OnPong: &proto.ServerMessage_Pong{PingID: cmd.Ping.PingID},
+						},
+					})
+					continue
+				case *proto.ClientMessage_Pong:
+					hb.received()
+					if rtt, ok := hb.pong(cmd.Pong.PingID); ok {
+						props := map[string]string{
+							gameserver.RTTPropertyKey: strconv.FormatInt(rtt.Milliseconds(), 10),
+						}
+						for _, roomID := range actor.Rooms() {
+							actor.SetProperties(ctx, roomID, props)
+						}
+					}
+					continue
+				}
+				hb.active()
+
+				roomID := quark.RoomID(in.RoomID)
+				reject := func(roomID quark.RoomID, err error) {
+					send(toServerMessage(commandError{roomID: roomID, correlationID: in.CorrelationID, err: err, cmd: commandOf(in)}))
+				}
+
 				switch cmd := in.Command.(type) {
 				case *proto.ClientMessage_JoinRoom:
 					roomID := quark.RoomID(cmd.JoinRoom.RoomID)
-					if ok := s.roomSet.JoinRoom(roomID, actor); ok {
-						onJoined <- struct{}{}
+					if err := s.roomSet.JoinRoom(ctx, roomID, actor); err != nil {
+						reject(roomID, err)
 					} else {
-						msg := toServerMessage(commandError{code: "001", detail: "room does not exist", cmd: cmd.JoinRoom})
-						if err := stream.Send(msg); err != nil {
-							fail <- err
-						}
+						onJoined <- commandResult{roomID: roomID, correlationID: in.CorrelationID}
 					}
 				case *proto.ClientMessage_SendMessage:
-					ok := actor.BroadcastToRoom(gameserver.Payload{
-						Code: cmd.SendMessage.Message.Code,
-						Body: cmd.SendMessage.Message.Payload})
-					if !ok {
-						msg := toServerMessage(commandError{code: "001", detail: "room does not exist", cmd: cmd.SendMessage})
-						if err := stream.Send(msg); err != nil {
-							fail <- err
-						}
+					p := gameserver.Payload{Code: cmd.SendMessage.Message.GetCode(), Body: cmd.SendMessage.Message.GetPayload()}
+					o := gameserver.MessageOptions{
+						CoalesceKey:   cmd.SendMessage.CoalesceKey,
+						CorrelationID: in.CorrelationID,
+						Ack:           cmd.SendMessage.Ack,
+					}
+					var err error
+					if cmd.SendMessage.Target == proto.ClientMessage_SendMessageCommand_TEAM {
+						err = actor.BroadcastToTeam(ctx, roomID, p, o)
+					} else {
+						err = actor.BroadcastToRoom(ctx, roomID, p, o)
+					}
+					if err != nil {
+						reject(roomID, err)
 					}
 				case *proto.ClientMessage_LeaveRoom:
-					actor.Leave()
-					onLeaved <- struct{}{}
+					if err := actor.Leave(ctx, roomID); err != nil {
+						reject(roomID, err)
+					} else {
+						onLeaved <- commandResult{roomID: roomID, correlationID: in.CorrelationID}
+					}
+				case *proto.ClientMessage_ChangeTeam:
+					if err := actor.ChangeTeam(ctx, roomID, gameserver.TeamID(cmd.ChangeTeam.Team)); err != nil {
+						reject(roomID, err)
+					}
+				case *proto.ClientMessage_SwapTeams:
+					a1 := gameserver.ActorID(cmd.SwapTeams.ActorID1)
+					a2 := gameserver.ActorID(cmd.SwapTeams.ActorID2)
+					if err := actor.SwapTeams(ctx, roomID, a1, a2); err != nil {
+						reject(roomID, err)
+					}
+				case *proto.ClientMessage_SendRequest:
+					req := cmd.SendRequest
+					p := gameserver.Payload{Code: req.Message.GetCode(), Body: req.Message.GetPayload()}
+					timeout := time.Duration(req.TimeoutMillis) * time.Millisecond
+					o := gameserver.MessageOptions{CorrelationID: in.CorrelationID}
+					if err := actor.Request(ctx, roomID, gameserver.ActorID(req.TargetActorID), req.RequestID, p, timeout, o); err != nil {
+						reject(roomID, err)
+					}
+				case *proto.ClientMessage_SendResponse:
+					res := cmd.SendResponse
+					p := gameserver.Payload{Code: res.Message.GetCode(), Body: res.Message.GetPayload()}
+					if err := actor.Respond(ctx, roomID, gameserver.ActorID(res.RequesterActorID), res.RequestID, p); err != nil {
+						reject(roomID, err)
+					}
 				}
 			}
 		}
@@ -87,48 +225,54 @@ func (s *roomServer) Service(stream proto.Room_ServiceServer) error {
 
 	// send loop
 	go func() {
-		inbox := actor.Inbox()
-
 		for {
 			select {
 			case <-stream.Context().Done():
 				return
-			case <-onJoined:
-				inbox = actor.Inbox()
-
+			case res, ok := <-onJoined:
+				if !ok {
+					onJoined = nil
+					continue
+				}
 				msg := proto.ServerMessage{
+					RoomID:        res.roomID.Uint64(),
+					CorrelationID: res.correlationID,
 					Event: &proto.ServerMessage_OnJoinRoomSuccess{
 						OnJoinRoomSuccess: &proto.ServerMessage_JoinRoomSuccess{
 							ActorID: actor.ActorID().String(),
 						},
 					},
 				}
-				if err := stream.Send(&msg); err != nil {
-					fail <- err
+				send(&msg)
+				go forward(res.roomID, actor.Inbox(res.roomID))
+			case res, ok := <-onLeaved:
+				if !ok {
+					onLeaved = nil
+					continue
 				}
-			case <-onLeaved:
-				inbox = actor.Inbox()
-
 				msg := proto.ServerMessage{
+					RoomID:        res.roomID.Uint64(),
+					CorrelationID: res.correlationID,
 					Event: &proto.ServerMessage_OnLeaveRoomSuccess{
 						OnLeaveRoomSuccess: &proto.ServerMessage_LeaveRoomSuccess{},
 					},
 				}
-				if err := stream.Send(&msg); err != nil {
-					fail <- err
-				}
-			case m, ok := <-inbox:
-				if !ok {
+				send(&msg)
+			case rm := <-inbox:
+				roomID := rm.roomID
+				if !actor.InRoom(roomID) {
+					// left the room; drop the rest of its messages
 					continue
 				}
 
-				switch m := m.(type) {
+				var msg *proto.ServerMessage
+				switch m := rm.message.(type) {
 				case gameserver.ActorMessage:
 					if actor.IsOwnMessage(&m) {
 						// skip send
 						continue
 					}
-					msg := proto.ServerMessage{
+					msg = &proto.ServerMessage{
 						Event: &proto.ServerMessage_OnMessageReceived{
 							OnMessageReceived: &proto.ServerMessage_ReceivedMessageEvent{
 								SenderID: m.Sender.String(),
@@ -139,47 +283,157 @@ func (s *roomServer) Service(stream proto.Room_ServiceServer) error {
 							},
 						},
 					}
-					if err := stream.Send(&msg); err != nil {
-						fail <- err
-					}
 				case gameserver.JoinRoomEvent:
-					ids := make([]string, len(m.ActorList))
-					for i, a := range m.ActorList {
-						ids[i] = a.String()
-					}
-					msg := proto.ServerMessage{
+					ids := toActorIDList(m.ActorList)
+					msg = &proto.ServerMessage{
 						Event: &proto.ServerMessage_OnJoinRoom{
 							OnJoinRoom: &proto.ServerMessage_JoinRoom{
 								ActorIDList: ids,
 								NewActorID:  m.NewActor.String(),
+								Teams:       toProtoTeams(m.Teams),
+								BotIDList:   toActorIDList(m.BotList),
 							},
 						},
 					}
-					if err := stream.Send(&msg); err != nil {
-						fail <- err
-					}
 				case gameserver.LeaveRoomEvent:
-					ids := make([]string, len(m.ActorList))
-					for i, a := range m.ActorList {
-						ids[i] = a.String()
+					if m.RemovedActor == actor.ActorID() {
+						// removed by the room, e.g. kicked
+						actor.LeaveWithReason(ctx, roomID, m.Reason, m.Detail)
 					}
-					msg := proto.ServerMessage{
+					ids := toActorIDList(m.ActorList)
+					msg = &proto.ServerMessage{
 						Event: &proto.ServerMessage_OnLeaveRoom{
 							OnLeaveRoom: &proto.ServerMessage_LeaveRoom{
 								ActorIDList:    ids,
 								RemovedActorID: m.RemovedActor.String(),
+								Teams:          toProtoTeams(m.Teams),
+								BotIDList:      toActorIDList(m.BotList),
+								Reason:         toProtoLeaveReason(m.Reason),
+								ReasonDetail:   m.Detail,
+							},
+						},
+					}
+				case gameserver.RequestMessage:
+					msg = &proto.ServerMessage{
+						Event: &proto.ServerMessage_OnRequestReceived{
+							OnRequestReceived: &proto.ServerMessage_ReceivedRequestEvent{
+								SenderID:  m.Sender.String(),
+								RequestID: m.RequestID,
+								Message: &proto.Message{
+									Code:    m.Code,
+									Payload: m.Payload,
+								},
+							},
+						},
+					}
+				case gameserver.ResponseMessage:
+					if m.Err != nil {
+						msg = toServerMessage(commandError{correlationID: m.CorrelationID, err: m.Err, response: true, cmd: &proto.ClientMessage_SendRequestCommand{
+							TargetActorID: m.Sender.String(),
+							RequestID:     m.RequestID,
+						}})
+					} else {
+						msg = &proto.ServerMessage{
+							CorrelationID: m.CorrelationID,
+							Event: &proto.ServerMessage_OnResponseReceived{
+								OnResponseReceived: &proto.ServerMessage_ReceivedResponseEvent{
+									ResponderID: m.Sender.String(),
+									RequestID:   m.RequestID,
+									Message: &proto.Message{
+										Code:    m.Code,
+										Payload: m.Payload,
+									},
+								},
+							},
+						}
+					}
+				case gameserver.ActorPropertiesChangedEvent:
+					msg = &proto.ServerMessage{
+						Event: &proto.ServerMessage_OnActorPropertiesChanged{
+							OnActorPropertiesChanged: &proto.ServerMessage_ActorPropertiesChanged{
+								ActorID:    m.Actor.String(),
+								Properties: m.Properties,
+							},
+						},
+					}
+				case gameserver.RoomClosedEvent:
+					actor.LeaveWithReason(ctx, roomID, m.Reason, m.Detail)
+					msg = &proto.ServerMessage{
+						Event: &proto.ServerMessage_OnRoomClosed{
+							OnRoomClosed: &proto.ServerMessage_RoomClosed{
+								Reason: toProtoLeaveReason(m.Reason),
+								Detail: m.Detail,
 							},
 						},
 					}
-					if err := stream.Send(&msg); err != nil {
-						fail <- err
+				case gameserver.MessageRejectedEvent:
+					target := proto.ClientMessage_SendMessageCommand_ROOM
+					if m.Message.TeamOnly {
+						target = proto.ClientMessage_SendMessageCommand_TEAM
+					}
+					msg = toServerMessage(commandError{correlationID: m.Message.CorrelationID, err: &rejectedError{err: m.Err}, cmd: &proto.ClientMessage_SendMessageCommand{
+						Message:     &proto.Message{Code: m.Message.Code, Payload: m.Message.Payload},
+						Target:      target,
+						CoalesceKey: m.Message.CoalesceKey,
+						Ack:         m.Message.Ack,
+					}})
+				case gameserver.MessageAcceptedEvent:
+					msg = &proto.ServerMessage{
+						CorrelationID: m.Message.CorrelationID,
+						Event: &proto.ServerMessage_OnMessageAck{
+							OnMessageAck: &proto.ServerMessage_MessageAck{},
+						},
+					}
+				case gameserver.TeamChangedEvent:
+					msg = &proto.ServerMessage{
+						Event: &proto.ServerMessage_OnTeamChanged{
+							OnTeamChanged: &proto.ServerMessage_TeamChanged{
+								Teams: toProtoTeams(m.Teams),
+							},
+						},
 					}
+				default:
+					continue
 				}
-
+				msg.RoomID = roomID.Uint64()
+				send(msg)
 			}
 		}
 	}()
 
+	// heartbeat loop
+	if 0 < s.opts.PingInterval || 0 < s.opts.IdleTimeout || 0 < s.opts.AFKTimeout {
+		go func() {
+			ticker := time.NewTicker(s.opts.heartbeatInterval())
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-stream.Context().Done():
+					return
+				case <-ticker.C:
+					if 0 < s.opts.IdleTimeout && s.opts.IdleTimeout < hb.sinceReceived() {
+						actor.LeaveAll(ctx, gameserver.LeaveReasonTimedOut, "idle timeout")
+						abort(status.Errorf(codes.DeadlineExceeded, "idle timeout"))
+						return
+					}
+					if 0 < s.opts.AFKTimeout && s.opts.AFKTimeout < hb.sinceActive() {
+						actor.LeaveAll(ctx, gameserver.LeaveReasonTimedOut, "AFK timeout")
+						abort(status.Errorf(codes.DeadlineExceeded, "AFK timeout"))
+						return
+					}
+					if 0 < s.opts.PingInterval && s.opts.PingInterval <= hb.sincePing() {
+						sender.sendNow(&proto.ServerMessage{
+							Event: &proto.ServerMessage_OnPing{
+								OnPing: &proto.ServerMessage_Ping{PingID: hb.ping()},
+							},
+						})
+					}
+				}
+			}
+		}()
+	}
+
 	// error handling loop
 	for {
 		select {
@@ -191,41 +445,119 @@ func (s *roomServer) Service(stream proto.Room_ServiceServer) error {
 	}
 }
 
+func newActor(ctx context.Context) *gameserver.Actor {
+	m, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(m[UserIDMetadataKey]) == 0 {
+		return gameserver.NewActor()
+	}
+	return gameserver.NewActorForUser(m[UserIDMetadataKey][0])
+}
+
+type roomMessage struct {
+	roomID  quark.RoomID
+	message gameserver.Message
+}
+
+// commandResult is the success of a command handled in the send loop
+type commandResult struct {
+	roomID        quark.RoomID
+	correlationID uint64
+}
+
 type commandError struct {
-	code   string
-	detail string
-	cmd    interface{}
+	roomID        quark.RoomID
+	correlationID uint64
+	err           error
+	cmd           interface{}
+	// response is set for the failure response of a request
+	response bool
 }
 
 func toServerMessage(c commandError) *proto.ServerMessage {
-	var cmdErr *proto.ServerMessage_CommandError
+	cmdErr := &proto.ServerMessage_CommandError{
+		ErrorCode:     toLegacyErrorCode(c),
+		Code:          toErrorCode(c.err),
+		ErrorDetail:   c.err.Error(),
+		Details:       toErrorDetails(c.err),
+		CorrelationID: c.correlationID,
+	}
 	switch cmd := c.cmd.(type) {
 	case *proto.ClientMessage_JoinRoomCommand:
-		cmdErr = &proto.ServerMessage_CommandError{
-			ErrorCode:   c.code,
-			ErrorDetail: c.detail,
-			ErrorCommand: &proto.ServerMessage_CommandError_JoinRoom{
-				JoinRoom: cmd,
-			},
-		}
+		cmdErr.ErrorCommand = &proto.ServerMessage_CommandError_JoinRoom{JoinRoom: cmd}
 	case *proto.ClientMessage_SendMessageCommand:
-		cmdErr = &proto.ServerMessage_CommandError{
-			ErrorCode:   c.code,
-			ErrorDetail: c.detail,
-			ErrorCommand: &proto.ServerMessage_CommandError_SendMessage{
-				SendMessage: cmd,
-			},
-		}
-	default:
-		cmdErr = &proto.ServerMessage_CommandError{
-			ErrorCode:   c.code,
-			ErrorDetail: c.detail,
-		}
+		cmdErr.ErrorCommand = &proto.ServerMessage_CommandError_SendMessage{SendMessage: cmd}
+	case *proto.ClientMessage_LeaveRoomCommand:
+		cmdErr.ErrorCommand = &proto.ServerMessage_CommandError_LeaveRoom{LeaveRoom: cmd}
+	case *proto.ClientMessage_ChangeTeamCommand:
+		cmdErr.ErrorCommand = &proto.ServerMessage_CommandError_ChangeTeam{ChangeTeam: cmd}
+	case *proto.ClientMessage_SwapTeamsCommand:
+		cmdErr.ErrorCommand = &proto.ServerMessage_CommandError_SwapTeams{SwapTeams: cmd}
+	case *proto.ClientMessage_SendRequestCommand:
+		cmdErr.ErrorCommand = &proto.ServerMessage_CommandError_SendRequest{SendRequest: cmd}
+	case *proto.ClientMessage_SendResponseCommand:
+		cmdErr.ErrorCommand = &proto.ServerMessage_CommandError_SendResponse{SendResponse: cmd}
 	}
 
 	return &proto.ServerMessage{
+		RoomID:        c.roomID.Uint64(),
+		CorrelationID: c.correlationID,
 		Event: &proto.ServerMessage_OnCommandFailed{
 			OnCommandFailed: cmdErr,
 		},
 	}
 }
+
+// commandOf returns the command of the client message, as errorCommand of CommandError
+func commandOf(in *proto.ClientMessage) interface{} {
+	switch cmd := in.Command.(type) {
+	case *proto.ClientMessage_JoinRoom:
+		return cmd.JoinRoom
+	case *proto.ClientMessage_SendMessage:
+		return cmd.SendMessage
+	case *proto.ClientMessage_LeaveRoom:
+		return cmd.LeaveRoom
+	case *proto.ClientMessage_ChangeTeam:
+		return cmd.ChangeTeam
+	case *proto.ClientMessage_SwapTeams:
+		return cmd.SwapTeams
+	case *proto.ClientMessage_SendRequest:
+		return cmd.SendRequest
+	case *proto.ClientMessage_SendResponse:
+		return cmd.SendResponse
+	default:
+		return nil
+	}
+}
+
+func toProtoTeams(teams map[gameserver.ActorID]gameserver.TeamID) map[string]uint32 {
+	m := make(map[string]uint32, len(teams))
+	for id, team := range teams {
+		m[id.String()] = uint32(team)
+	}
+	return m
+}
+
+func toActorIDList(actors []gameserver.ActorID) []string {
+	ids := make([]string, len(actors))
+	for i, a := range actors {
+		ids[i] = a.String()
+	}
+	return ids
+}
+
+func toProtoLeaveReason(r gameserver.LeaveReason) proto.ServerMessage_LeaveRoom_Reason {
+	switch r {
+	case gameserver.LeaveReasonDisconnected:
+		return proto.ServerMessage_LeaveRoom_DISCONNECTED
+	case gameserver.LeaveReasonTimedOut:
+		return proto.ServerMessage_LeaveRoom_TIMED_OUT
+	case gameserver.LeaveReasonKicked:
+		return proto.ServerMessage_LeaveRoom_KICKED
+	case gameserver.LeaveReasonRoomClosed:
+		return proto.ServerMessage_LeaveRoom_ROOM_CLOSED
+	case gameserver.LeaveReasonServerShutdown:
+		return proto.ServerMessage_LeaveRoom_SERVER_SHUTDOWN
+	default:
+		return proto.ServerMessage_LeaveRoom_VOLUNTARY
+	}
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log"
 	"math/rand"
 	"net"
@@ -11,6 +12,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
 	"quark/gameserver"
@@ -176,6 +180,7 @@ func TestRoomServer_Service(t *testing.T) {
 		rand.Read(payload)
 
 		err := s1.Send(&proto.ClientMessage{
+			RoomID: roomID,
 			Command: &proto.ClientMessage_SendMessage{
 				SendMessage: &proto.ClientMessage_SendMessageCommand{
 					Message: &proto.Message{
@@ -195,6 +200,7 @@ func TestRoomServer_Service(t *testing.T) {
 		m, err := s2.Recv()
 		require.NoError(t, err)
 		require.IsType(t, m.Event, &proto.ServerMessage_OnMessageReceived{})
+		assert.Equal(t, roomID, m.RoomID)
 
 		ev := m.Event.(*proto.ServerMessage_OnMessageReceived)
 		msg := ev.OnMessageReceived
@@ -220,6 +226,7 @@ func TestRoomServer_Service(t *testing.T) {
 	// c3: leave
 	{
 		err := s3.Send(&proto.ClientMessage{
+			RoomID: roomID,
 			Command: &proto.ClientMessage_LeaveRoom{
 				LeaveRoom: &proto.ClientMessage_LeaveRoomCommand{},
 			},
@@ -237,6 +244,7 @@ func TestRoomServer_Service(t *testing.T) {
 
 		ev := m.Event.(*proto.ServerMessage_OnLeaveRoom)
 		assert.Len(t, ev.OnLeaveRoom.ActorIDList, 2)
+		assert.Equal(t, proto.ServerMessage_LeaveRoom_VOLUNTARY, ev.OnLeaveRoom.Reason)
 	}
 	{
 		m, err := s2.Recv()
@@ -276,3 +284,483 @@ func listenDialOption(lis *bufconn.Listener) grpc.DialOption {
 		return lis.Dial()
 	})
 }
+
+func TestRoomServer_Heartbeat(t *testing.T) {
+	roomServer := &roomServer{
+		roomSet: gameserver.NewRoomSet(),
+		opts: RoomServerOptions{
+			PingInterval: 20 * time.Millisecond,
+			IdleTimeout:  200 * time.Millisecond,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenRoomServer(ctx, roomServer)
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	cli := proto.NewRoomClient(conn)
+
+	resp, err := cli.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: "heartbeat"})
+	require.NoError(t, err)
+
+	stream, err := cli.Service(ctx)
+	require.NoError(t, err)
+
+	err = stream.Send(&proto.ClientMessage{
+		Command: &proto.ClientMessage_JoinRoom{
+			JoinRoom: &proto.ClientMessage_JoinRoomCommand{RoomID: resp.RoomID},
+		},
+	})
+	require.NoError(t, err)
+
+	var actorID string
+	var pingID uint64
+	for actorID == "" || pingID == 0 {
+		m, err := stream.Recv()
+		require.NoError(t, err)
+		switch ev := m.Event.(type) {
+		case *proto.ServerMessage_OnJoinRoomSuccess:
+			actorID = ev.OnJoinRoomSuccess.ActorID
+		case *proto.ServerMessage_OnPing:
+			pingID = ev.OnPing.PingID
+		}
+	}
+
+	err = stream.Send(&proto.ClientMessage{
+		Command: &proto.ClientMessage_Pong{
+			Pong: &proto.ClientMessage_PongCommand{PingID: pingID},
+		},
+	})
+	require.NoError(t, err)
+
+	for {
+		m, err := stream.Recv()
+		require.NoError(t, err)
+		if ev, ok := m.Event.(*proto.ServerMessage_OnActorPropertiesChanged); ok {
+			assert.Equal(t, actorID, ev.OnActorPropertiesChanged.ActorID)
+			assert.Contains(t, ev.OnActorPropertiesChanged.Properties, gameserver.RTTPropertyKey)
+			break
+		}
+	}
+
+	// no more pongs
+	for {
+		_, err := stream.Recv()
+		if err != nil {
+			assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+			break
+		}
+	}
+}
+
+func TestRoomServer_MultipleRooms(t *testing.T) {
+	roomServer := &roomServer{roomSet: gameserver.NewRoomSet()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenRoomServer(ctx, roomServer)
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	cli := proto.NewRoomClient(conn)
+
+	r1, err := cli.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: "match"})
+	require.NoError(t, err)
+	r2, err := cli.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: "guild"})
+	require.NoError(t, err)
+
+	s1, err := cli.Service(ctx)
+	require.NoError(t, err)
+	s2, err := cli.Service(ctx)
+	require.NoError(t, err)
+
+	join := func(s proto.Room_ServiceClient, roomID uint64) {
+		err := s.Send(&proto.ClientMessage{
+			Command: &proto.ClientMessage_JoinRoom{
+				JoinRoom: &proto.ClientMessage_JoinRoomCommand{RoomID: roomID},
+			},
+		})
+		require.NoError(t, err)
+
+		m, err := s.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Event, &proto.ServerMessage_OnJoinRoomSuccess{})
+		assert.Equal(t, roomID, m.RoomID)
+	}
+	join(s1, r1.RoomID)
+	join(s1, r2.RoomID)
+	join(s2, r2.RoomID)
+	{
+		m, err := s1.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Event, &proto.ServerMessage_OnJoinRoom{})
+		assert.Equal(t, r2.RoomID, m.RoomID)
+	}
+
+	err = s2.Send(&proto.ClientMessage{
+		RoomID: r2.RoomID,
+		Command: &proto.ClientMessage_SendMessage{
+			SendMessage: &proto.ClientMessage_SendMessageCommand{
+				Message: &proto.Message{Code: 0x01, Payload: []byte("guild")},
+			},
+		},
+	})
+	require.NoError(t, err)
+	{
+		m, err := s1.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Event, &proto.ServerMessage_OnMessageReceived{})
+		assert.Equal(t, r2.RoomID, m.RoomID)
+		assert.Equal(t, []byte("guild"), m.Event.(*proto.ServerMessage_OnMessageReceived).OnMessageReceived.Message.Payload)
+	}
+
+	// s2 is not in the match room
+	err = s2.Send(&proto.ClientMessage{
+		RoomID: r1.RoomID,
+		Command: &proto.ClientMessage_SendMessage{
+			SendMessage: &proto.ClientMessage_SendMessageCommand{
+				Message: &proto.Message{Code: 0x01},
+			},
+		},
+	})
+	require.NoError(t, err)
+	{
+		m, err := s2.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Event, &proto.ServerMessage_OnCommandFailed{})
+		assert.Equal(t, r1.RoomID, m.RoomID)
+	}
+
+	// leaving one room keeps the other membership
+	err = s1.Send(&proto.ClientMessage{
+		RoomID: r2.RoomID,
+		Command: &proto.ClientMessage_LeaveRoom{
+			LeaveRoom: &proto.ClientMessage_LeaveRoomCommand{},
+		},
+	})
+	require.NoError(t, err)
+	{
+		m, err := s1.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Event, &proto.ServerMessage_OnLeaveRoomSuccess{})
+		assert.Equal(t, r2.RoomID, m.RoomID)
+	}
+	{
+		m, err := s2.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Event, &proto.ServerMessage_OnLeaveRoom{})
+		assert.Equal(t, r2.RoomID, m.RoomID)
+	}
+	join(s2, r1.RoomID)
+	{
+		m, err := s1.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Event, &proto.ServerMessage_OnJoinRoom{})
+		assert.Equal(t, r1.RoomID, m.RoomID)
+	}
+}
+
+func TestRoomServer_Batching(t *testing.T) {
+	roomServer := &roomServer{
+		roomSet: gameserver.NewRoomSet(),
+		opts: RoomServerOptions{
+			BatchWindow: 50 * time.Millisecond,
+			BatchSize:   3,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenRoomServer(ctx, roomServer)
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	cli := proto.NewRoomClient(conn)
+
+	resp, err := cli.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: "batch"})
+	require.NoError(t, err)
+
+	s1, err := cli.Service(ctx)
+	require.NoError(t, err)
+	s2, err := cli.Service(ctx)
+	require.NoError(t, err)
+
+	for _, s := range []proto.Room_ServiceClient{s1, s2} {
+		err := s.Send(&proto.ClientMessage{
+			Command: &proto.ClientMessage_JoinRoom{
+				JoinRoom: &proto.ClientMessage_JoinRoomCommand{RoomID: resp.RoomID},
+			},
+		})
+		require.NoError(t, err)
+		m, err := s.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Event, &proto.ServerMessage_OnJoinRoomSuccess{})
+	}
+
+	for i := 0; i < 5; i++ {
+		err := s2.Send(&proto.ClientMessage{
+			RoomID: resp.RoomID,
+			Command: &proto.ClientMessage_SendMessage{
+				SendMessage: &proto.ClientMessage_SendMessageCommand{
+					Message: &proto.Message{Code: uint32(i)},
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	var received []uint32
+	batches := 0
+	for len(received) < 5 {
+		m, err := s1.Recv()
+		require.NoError(t, err)
+
+		events := []*proto.ServerMessage{m}
+		if ev, ok := m.Event.(*proto.ServerMessage_OnBatch); ok {
+			batches += 1
+			assert.LessOrEqual(t, len(ev.OnBatch.Messages), 3)
+			events = ev.OnBatch.Messages
+		}
+		for _, m := range events {
+			if ev, ok := m.Event.(*proto.ServerMessage_OnMessageReceived); ok {
+				assert.Equal(t, resp.RoomID, m.RoomID)
+				received = append(received, ev.OnMessageReceived.Message.Code)
+			}
+		}
+	}
+	assert.Equal(t, []uint32{0, 1, 2, 3, 4}, received)
+	assert.Positive(t, batches)
+}
+
+func TestRoomServer_Interceptors(t *testing.T) {
+	users := make(chan string, 1)
+	roomServer := &roomServer{
+		roomSet: gameserver.NewRoomSet(),
+		opts: RoomServerOptions{
+			Interceptors: map[string][]gameserver.MessageInterceptor{
+				"chat": {
+					func(info gameserver.MessageInfo, m gameserver.ActorMessage, next gameserver.MessageHandler) error {
+						users <- info.User
+						return errors.New("rejected")
+					},
+				},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenRoomServer(ctx, roomServer)
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	cli := proto.NewRoomClient(conn)
+
+	resp, err := cli.CreateRoom(ctx, &proto.CreateRoomRequest{
+		RoomName:    "chat",
+		RoomOptions: &proto.RoomOptions{RoomType: "chat"},
+	})
+	require.NoError(t, err)
+
+	stream, err := cli.Service(metadata.AppendToOutgoingContext(ctx, UserIDMetadataKey, "alice"))
+	require.NoError(t, err)
+
+	err = stream.Send(&proto.ClientMessage{
+		Command: &proto.ClientMessage_JoinRoom{
+			JoinRoom: &proto.ClientMessage_JoinRoomCommand{RoomID: resp.RoomID},
+		},
+	})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	err = stream.Send(&proto.ClientMessage{
+		RoomID:        resp.RoomID,
+		CorrelationID: 7,
+		Command: &proto.ClientMessage_SendMessage{
+			SendMessage: &proto.ClientMessage_SendMessageCommand{
+				Message: &proto.Message{Code: 0x01, Payload: []byte("hi")},
+				Ack:     true,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// rejected instead of acked
+	m, err := stream.Recv()
+	require.NoError(t, err)
+	require.IsType(t, m.Event, &proto.ServerMessage_OnCommandFailed{})
+	assert.Equal(t, uint64(7), m.CorrelationID)
+	ev := m.Event.(*proto.ServerMessage_OnCommandFailed).OnCommandFailed
+	assert.Equal(t, proto.ServerMessage_CommandError_REJECTED, ev.Code)
+	assert.Equal(t, uint64(7), ev.CorrelationID)
+	assert.Equal(t, "rejected", ev.ErrorDetail)
+	assert.Equal(t, []byte("hi"), ev.GetSendMessage().Message.Payload)
+	assert.Equal(t, "alice", <-users)
+}
+
+func TestRoomServer_CommandErrors(t *testing.T) {
+	roomServer := &roomServer{
+		roomSet: gameserver.NewRoomSet(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenRoomServer(ctx, roomServer)
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	cli := proto.NewRoomClient(conn)
+
+	resp, err := cli.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: "errors"})
+	require.NoError(t, err)
+
+	stream, err := cli.Service(ctx)
+	require.NoError(t, err)
+
+	recvError := func() *proto.ServerMessage_CommandError {
+		m, err := stream.Recv()
+		require.NoError(t, err)
+		require.IsType(t, m.Event, &proto.ServerMessage_OnCommandFailed{})
+		return m.Event.(*proto.ServerMessage_OnCommandFailed).OnCommandFailed
+	}
+
+	// room not found
+	err = stream.Send(&proto.ClientMessage{
+		CorrelationID: 1,
+		Command: &proto.ClientMessage_JoinRoom{
+			JoinRoom: &proto.ClientMessage_JoinRoomCommand{RoomID: resp.RoomID + 1},
+		},
+	})
+	require.NoError(t, err)
+	ev := recvError()
+	assert.Equal(t, proto.ServerMessage_CommandError_ROOM_NOT_FOUND, ev.Code)
+	assert.Equal(t, "001", ev.ErrorCode)
+	assert.Equal(t, uint64(1), ev.CorrelationID)
+	assert.NotNil(t, ev.GetJoinRoom())
+
+	// not member
+	err = stream.Send(&proto.ClientMessage{
+		RoomID:        resp.RoomID,
+		CorrelationID: 2,
+		Command: &proto.ClientMessage_LeaveRoom{
+			LeaveRoom: &proto.ClientMessage_LeaveRoomCommand{},
+		},
+	})
+	require.NoError(t, err)
+	ev = recvError()
+	assert.Equal(t, proto.ServerMessage_CommandError_NOT_MEMBER, ev.Code)
+	assert.Equal(t, uint64(2), ev.CorrelationID)
+	assert.NotNil(t, ev.GetLeaveRoom())
+
+	// not member, with the legacy code of the command
+	err = stream.Send(&proto.ClientMessage{
+		RoomID:        resp.RoomID,
+		CorrelationID: 3,
+		Command: &proto.ClientMessage_ChangeTeam{
+			ChangeTeam: &proto.ClientMessage_ChangeTeamCommand{Team: 1},
+		},
+	})
+	require.NoError(t, err)
+	ev = recvError()
+	assert.Equal(t, proto.ServerMessage_CommandError_NOT_MEMBER, ev.Code)
+	assert.Equal(t, "002", ev.ErrorCode)
+	assert.Equal(t, uint64(3), ev.CorrelationID)
+}
+
+func TestRoomServer_CorrelationID(t *testing.T) {
+	roomServer := &roomServer{
+		roomSet: gameserver.NewRoomSet(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	lis := listenRoomServer(ctx, roomServer)
+	conn, err := grpc.DialContext(ctx, "bufnet", listenDialOption(lis), grpc.WithInsecure())
+	require.NoError(t, err)
+	cli := proto.NewRoomClient(conn)
+
+	resp, err := cli.CreateRoom(ctx, &proto.CreateRoomRequest{RoomName: "correlation"})
+	require.NoError(t, err)
+
+	stream, err := cli.Service(ctx)
+	require.NoError(t, err)
+
+	err = stream.Send(&proto.ClientMessage{
+		CorrelationID: 1,
+		Command: &proto.ClientMessage_JoinRoom{
+			JoinRoom: &proto.ClientMessage_JoinRoomCommand{RoomID: resp.RoomID},
+		},
+	})
+	require.NoError(t, err)
+	m, err := stream.Recv()
+	require.NoError(t, err)
+	assert.IsType(t, m.Event, &proto.ServerMessage_OnJoinRoomSuccess{})
+	assert.Equal(t, uint64(1), m.CorrelationID)
+
+	err = stream.Send(&proto.ClientMessage{
+		RoomID:        resp.RoomID,
+		CorrelationID: 2,
+		Command: &proto.ClientMessage_SendMessage{
+			SendMessage: &proto.ClientMessage_SendMessageCommand{
+				Message: &proto.Message{Code: 0x01},
+				Ack:     true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	m, err = stream.Recv()
+	require.NoError(t, err)
+	assert.IsType(t, m.Event, &proto.ServerMessage_OnMessageAck{})
+	assert.Equal(t, uint64(2), m.CorrelationID)
+	assert.Equal(t, resp.RoomID, m.RoomID)
+
+	// the failure response of a request
+	err = stream.Send(&proto.ClientMessage{
+		RoomID:        resp.RoomID,
+		CorrelationID: 5,
+		Command: &proto.ClientMessage_SendRequest{
+			SendRequest: &proto.ClientMessage_SendRequestCommand{
+				TargetActorID: "nobody",
+				RequestID:     1,
+				Message:       &proto.Message{Code: 0x01},
+			},
+		},
+	})
+	require.NoError(t, err)
+	m, err = stream.Recv()
+	require.NoError(t, err)
+	require.IsType(t, m.Event, &proto.ServerMessage_OnCommandFailed{})
+	assert.Equal(t, uint64(5), m.CorrelationID)
+	assert.Equal(t, proto.ServerMessage_CommandError_REQUEST_TARGET_NOT_FOUND, m.Event.(*proto.ServerMessage_OnCommandFailed).OnCommandFailed.Code)
+
+	err = stream.Send(&proto.ClientMessage{
+		RoomID:        resp.RoomID,
+		CorrelationID: 3,
+		Command: &proto.ClientMessage_LeaveRoom{
+			LeaveRoom: &proto.ClientMessage_LeaveRoomCommand{},
+		},
+	})
+	require.NoError(t, err)
+	m, err = stream.Recv()
+	require.NoError(t, err)
+	assert.IsType(t, m.Event, &proto.ServerMessage_OnLeaveRoomSuccess{})
+	assert.Equal(t, uint64(3), m.CorrelationID)
+
+	err = stream.Send(&proto.ClientMessage{
+		RoomID:        resp.RoomID,
+		CorrelationID: 4,
+		Command: &proto.ClientMessage_LeaveRoom{
+			LeaveRoom: &proto.ClientMessage_LeaveRoomCommand{},
+		},
+	})
+	require.NoError(t, err)
+	m, err = stream.Recv()
+	require.NoError(t, err)
+	require.IsType(t, m.Event, &proto.ServerMessage_OnCommandFailed{})
+	assert.Equal(t, uint64(4), m.CorrelationID)
+	assert.Equal(t, uint64(4), m.GetOnCommandFailed().CorrelationID)
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+
+	"quark/masterserver"
+	"quark/proto"
+)
+
+// statsFeed computes the fleet stats once per interval and fans them out to
+// the InLobby streams. It only runs while a stream is subscribed.
+type statsFeed struct {
+	fleet    *masterserver.Fleet
+	interval time.Duration
+
+	mu   sync.Mutex
+	subs map[chan *proto.FleetStats]bool
+	last *proto.FleetStats
+	stop chan struct{}
+}
+
+func newStatsFeed(fleet *masterserver.Fleet, interval time.Duration) *statsFeed {
+	return &statsFeed{
+		fleet:    fleet,
+		interval: interval,
+		subs:     make(map[chan *proto.FleetStats]bool),
+	}
+}
+
+// subscribe returns a channel holding the latest stats; a subscriber which is
+// behind only gets the newest ones
+func (f *statsFeed) subscribe() chan *proto.FleetStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c := make(chan *proto.FleetStats, 1)
+	if len(f.subs) == 0 {
+		f.last = toProtoFleetStats(f.fleet.Stats())
+		f.stop = make(chan struct{})
+		go f.run(f.stop)
+	}
+	f.subs[c] = true
+	c <- f.last
+	return c
+}
+
+func (f *statsFeed) unsubscribe(c chan *proto.FleetStats) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.subs[c] {
+		return
+	}
+	delete(f.subs, c)
+	if len(f.subs) == 0 {
+		close(f.stop)
+		f.last = nil
+	}
+}
+
+func (f *statsFeed) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			stats := toProtoFleetStats(f.fleet.Stats())
+			f.mu.Lock()
+			select {
+			case <-stop:
+				f.mu.Unlock()
+				return
+			default:
+			}
+			f.last = stats
+			for c := range f.subs {
+				// replace the stats the subscriber has not taken yet
+				select {
+				case <-c:
+				default:
+				}
+				c <- stats
+			}
+			f.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package masterserver
+
+import (
+	"sort"
+	"time"
+
+	"quark"
+)
+
+// AddSeatReservationListener sends the seat reservations to c, which should
+// be buffered. The fleet closes c when it is removed or falls behind.
+func (f *Fleet) AddSeatReservationListener(c chan<- SeatsReservedEvent) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	f.reserveListeners[c] = true
+}
+
+func (f *Fleet) RemoveSeatReservationListener(c chan<- SeatsReservedEvent) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.reserveListeners[c] {
+		delete(f.reserveListeners, c)
+		close(c)
+	}
+}
+
+// RequestBackfill advertises the room as wanting b.Slots players, replacing
+// the previous backfill of the room. Slots of 0 cancels the backfill.
+func (f *Fleet) RequestBackfill(b Backfill) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	room, ok := f.rs[b.RoomID]
+	if !ok {
+		return ErrRoomStatusNotFound
+	}
+	if b.Slots == 0 {
+		delete(f.backfills, b.RoomID)
+	} else {
+		b.Mode = room.GameMode
+		b.Region = room.Region
+		f.backfills[b.RoomID] = b
+	}
+	f.setBackfillSlots(room, b.Slots)
+	return nil
+}
+
+// Backfills returns the backfills of the rooms, oldest rooms first
+func (f *Fleet) Backfills() []Backfill {
+	f.mux.RLock()
+	defer f.mux.RUnlock()
+
+	bs := make([]Backfill, 0, len(f.backfills))
+	for _, b := range f.backfills {
+		bs = append(bs, b)
+	}
+	sort.Slice(bs, func(i, j int) bool {
+		ri, rj := f.rs[bs[i].RoomID], f.rs[bs[j].RoomID]
+		if !ri.CreatedAt.Equal(rj.CreatedAt) {
+			return ri.CreatedAt.Before(rj.CreatedAt)
+		}
+		return bs[i].RoomID < bs[j].RoomID
+	})
+	return bs
+}
+
+// ReserveSeats reserves seats for the users in the allocated room and takes
+// them off its backfill. The game server of the room is told by the seat
+// reservation listeners, along with the teams of the users if not nil. If the seats do not fit in the room, ErrRoomFull is
+// returned and the backfill of the room is cancelled.
+func (f *Fleet) ReserveSeats(roomID quark.RoomID, userIDs []string, userTeams map[string]uint32, ttl time.Duration) (GameServerAddr, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	room, ok := f.rs[roomID]
+	if !ok {
+		return GameServerAddr{}, ErrRoomStatusNotFound
+	}
+	g := f.rg[roomID]
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	updated := *room
+	updated.ReservedUserIDs = nil
+	held := make(map[string]bool)
+	if 0 < room.ReservedSeats(now) {
+		updated.ReservedUserIDs = append(updated.ReservedUserIDs, room.ReservedUserIDs...)
+		for _, u := range room.ReservedUserIDs {
+			held[u] = true
+		}
+	}
+	// users holding a seat already renew it
+	for _, u := range userIDs {
+		if !held[u] {
+			held[u] = true
+			updated.ReservedUserIDs = append(updated.ReservedUserIDs, u)
+		}
+	}
+	if 0 < room.MaxActors && room.MaxActors < room.ActorCount+uint(len(updated.ReservedUserIDs)) {
+		delete(f.backfills, roomID)
+		f.setBackfillSlots(room, 0)
+		return GameServerAddr{}, ErrRoomFull
+	}
+	if updated.ReservationExpiresAt.Before(expiresAt) {
+		updated.ReservationExpiresAt = expiresAt
+	}
+	f.rs[roomID] = &updated
+
+	slots := uint(0)
+	if b, ok := f.backfills[roomID]; ok {
+		if uint(len(userIDs)) < b.Slots {
+			b.Slots -= uint(len(userIDs))
+			f.backfills[roomID] = b
+			slots = b.Slots
+		} else {
+			delete(f.backfills, roomID)
+		}
+	}
+	if !f.setBackfillSlots(&updated, slots) {
+		f.publishRoomEvent(RoomEvent{Type: RoomUpdated, Room: updated})
+	}
+
+	ev := SeatsReservedEvent{
+		GameServer: g.addr,
+		RoomID:     roomID,
+		UserIDs:    userIDs,
+		UserTeams:  userTeams,
+		ExpiresAt:  expiresAt,
+	}
+	for c := range f.reserveListeners {
+		select {
+		case c <- ev:
+		default:
+			delete(f.reserveListeners, c)
+			close(c)
+		}
+	}
+	return g.addr, nil
+}
+
+// setBackfillSlots updates the backfill slots of the room and reports whether
+// it has changed
+func (f *Fleet) setBackfillSlots(room *RoomStatus, slots uint) bool {
+	if room.BackfillSlots == slots {
+		return false
+	}
+	updated := *room
+	updated.BackfillSlots = slots
+	f.rs[room.RoomID] = &updated
+	f.publishRoomEvent(RoomEvent{Type: RoomUpdated, Room: updated})
+	return true
+}
@@ -2,8 +2,10 @@ package masterserver
 
 import (
 	"errors"
+	"math/rand"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -14,27 +16,46 @@ var (
 	ErrNotEnoughGameServers = errors.New("not enough game servers")
 	ErrRoomAlreadyAllocated = errors.New("room already allocated")
 	ErrRoomStatusNotFound   = errors.New("room status not found")
+	ErrRoomNotFound         = errors.New("room not found")
+	ErrRoomNameTaken        = errors.New("room name already taken")
+	ErrRoomNameRequired     = errors.New("room name is required")
+	ErrRoomFull             = errors.New("room is full")
 )
 
 type Fleet struct {
 	rs map[quark.RoomID]*RoomStatus
 	rg map[quark.RoomID]*GameServer
 	g  []*GameServer
+	// names of rooms, unique in the fleet
+	names map[string]quark.RoomID
+	// rooms by lobby
+	lobbies map[string]map[quark.RoomID]struct{}
 
-	allocListeners map[chan<- RoomAllocatedEvent]bool
+	backfills map[quark.RoomID]Backfill
+
+	allocListeners   map[chan<- RoomAllocatedEvent]bool
+	reserveListeners map[chan<- SeatsReservedEvent]bool
+	roomListeners    map[<-chan RoomEvent]roomListener
 
 	mux sync.RWMutex
 }
 
 func NewFleet() *Fleet {
 	return &Fleet{
-		rs:             make(map[quark.RoomID]*RoomStatus),
-		rg:             make(map[quark.RoomID]*GameServer),
-		g:              make([]*GameServer, 0),
-		allocListeners: make(map[chan<- RoomAllocatedEvent]bool),
+		rs:               make(map[quark.RoomID]*RoomStatus),
+		rg:               make(map[quark.RoomID]*GameServer),
+		g:                make([]*GameServer, 0),
+		names:            make(map[string]quark.RoomID),
+		lobbies:          make(map[string]map[quark.RoomID]struct{}),
+		backfills:        make(map[quark.RoomID]Backfill),
+		allocListeners:   make(map[chan<- RoomAllocatedEvent]bool),
+		reserveListeners: make(map[chan<- SeatsReservedEvent]bool),
+		roomListeners:    make(map[<-chan RoomEvent]roomListener),
 	}
 }
 
+// AddRoomAllocationListener sends the allocated rooms to c, which should be
+// buffered. The fleet closes c when it is removed or falls behind.
 func (f *Fleet) AddRoomAllocationListener(c chan<- RoomAllocatedEvent) {
 	f.mux.Lock()
 	defer f.mux.Unlock()
@@ -46,19 +67,90 @@ func (f *Fleet) RemoveRoomAllocationListener(c chan<- RoomAllocatedEvent) {
 	f.mux.Lock()
 	defer f.mux.Unlock()
 
-	delete(f.allocListeners, c)
+	if f.allocListeners[c] {
+		delete(f.allocListeners, c)
+		close(c)
+	}
+}
+
+// roomEventBufferSize is the number of room events a listener can fall behind
+// before it is dropped
+const roomEventBufferSize = 256
+
+type roomListener struct {
+	c     chan RoomEvent
+	lobby string
+}
+
+// SubscribeRoomList returns the current rooms of the lobby and a channel of
+// the changes after them. The channel is closed if the listener falls behind;
+// subscribe again for a new snapshot.
+func (f *Fleet) SubscribeRoomList(lobby string) ([]RoomStatus, <-chan RoomEvent) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	c := make(chan RoomEvent, roomEventBufferSize)
+	f.roomListeners[c] = roomListener{c: c, lobby: lobby}
+	return f.lobbyRoomList(lobby), c
+}
+
+func (f *Fleet) UnsubscribeRoomList(c <-chan RoomEvent) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if l, ok := f.roomListeners[c]; ok {
+		delete(f.roomListeners, c)
+		close(l.c)
+	}
+}
+
+func (f *Fleet) publishRoomEvent(ev RoomEvent) {
+	for k, l := range f.roomListeners {
+		if l.lobby != ev.Room.Lobby {
+			continue
+		}
+		select {
+		case l.c <- ev:
+		default:
+			delete(f.roomListeners, k)
+			close(l.c)
+		}
+	}
 }
 
 func (f *Fleet) RegisterGameServer(addr GameServerAddr, cap uint) GameServerID {
+	return f.RegisterGameServerInRegion(addr, "", cap)
+}
+
+func (f *Fleet) RegisterGameServerInRegion(addr GameServerAddr, region string, cap uint) GameServerID {
 	f.mux.Lock()
 	defer f.mux.Unlock()
 
 	id := GameServerID(uuid.Must(uuid.NewRandom()).String())
-	gs := newGameServer(id, addr, cap)
+	gs := newGameServer(id, addr, region, cap)
 	f.g = append(f.g, gs)
 	return id
 }
 
+// UnregisterGameServer removes the game server and its rooms
+func (f *Fleet) UnregisterGameServer(id GameServerID) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	for i, g := range f.g {
+		if g.id != id {
+			continue
+		}
+		f.g = append(f.g[:i], f.g[i+1:]...)
+		for roomID, rg := range f.rg {
+			if rg == g {
+				f.removeRoom(roomID)
+			}
+		}
+		return
+	}
+}
+
 func (f *Fleet) IsRegisteredGameServer(id GameServerID) bool {
 	f.mux.RLock()
 	defer f.mux.RUnlock()
@@ -71,33 +163,51 @@ func (f *Fleet) IsRegisteredGameServer(id GameServerID) bool {
 	return false
 }
 
-func (f *Fleet) AllocateRoom(roomID quark.RoomID, roomName string) (GameServerAddr, error) {
-	err := func() error {
-		f.mux.RLock()
-		defer f.mux.RUnlock()
+// AllocateRoom allocates the room on a game server with capacity.
+// A non-empty roomName must be unique in the fleet.
+func (f *Fleet) AllocateRoom(roomID quark.RoomID, roomName string, opts RoomOptions) (GameServerAddr, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
 
-		if len(f.g) == 0 {
-			return ErrNotEnoughGameServers
-		}
-		_, ok := f.rg[roomID]
-		if ok {
-			return ErrRoomAlreadyAllocated
-		}
-		return nil
-	}()
-	if err != nil {
-		return GameServerAddr{}, err
+	if _, ok := f.names[roomName]; ok && roomName != "" {
+		return GameServerAddr{}, ErrRoomNameTaken
+	}
+	return f.allocateRoom(roomID, roomName, opts)
+}
+
+// LoadOrAllocateRoom returns the room of the name if it exists, or allocates a
+// new room for the name. The loaded result is true if the room existed.
+func (f *Fleet) LoadOrAllocateRoom(roomName string, opts RoomOptions) (quark.RoomID, GameServerAddr, bool, error) {
+	if roomName == "" {
+		return 0, GameServerAddr{}, false, ErrRoomNameRequired
 	}
+
 	f.mux.Lock()
 	defer f.mux.Unlock()
 
+	if roomID, ok := f.names[roomName]; ok {
+		return roomID, f.rg[roomID].addr, true, nil
+	}
+	roomID := quark.NewRoomID()
+	addr, err := f.allocateRoom(roomID, roomName, opts)
+	return roomID, addr, false, err
+}
+
+func (f *Fleet) allocateRoom(roomID quark.RoomID, roomName string, opts RoomOptions) (GameServerAddr, error) {
+	if len(f.g) == 0 {
+		return GameServerAddr{}, ErrNotEnoughGameServers
+	}
+	if _, ok := f.rg[roomID]; ok {
+		return GameServerAddr{}, ErrRoomAlreadyAllocated
+	}
+
 	var lookup func(gs []*GameServer) *GameServer
 	lookup = func(gs []*GameServer) *GameServer {
 		if len(gs) == 0 {
 			return nil
 		}
 		g := gs[0]
-		if g.HasCapacity() {
+		if g.HasCapacity() && (opts.Region == "" || opts.Region == g.region) {
 			return g
 		}
 		return lookup(gs[1:])
@@ -108,48 +218,193 @@ func (f *Fleet) AllocateRoom(roomID quark.RoomID, roomName string) (GameServerAd
 		return GameServerAddr{}, ErrNotEnoughGameServers
 	}
 
-	err = g.AddRoom(roomID)
+	err := g.AddRoom(roomID)
 	if err != nil {
 		return GameServerAddr{}, err
 	}
 
-	room := RoomStatus{RoomID: roomID, RoomName: roomName, ActorCount: 0}
+	ttl := opts.ReservationTTL
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+	now := time.Now()
+	room := RoomStatus{
+		RoomID:     roomID,
+		RoomName:   roomName,
+		MaxActors:  opts.MaxActors,
+		Open:       true,
+		Visible:    !opts.Hidden,
+		Locked:     opts.Locked,
+		GameMode:   opts.GameMode,
+		Region:     g.region,
+		CreatedAt:  now,
+		Properties: opts.lobbyProperties(),
+		Lobby:      opts.Lobby,
+	}
+	if len(opts.ReservedUserIDs) != 0 {
+		room.ReservedUserIDs = append([]string(nil), opts.ReservedUserIDs...)
+		room.ReservationExpiresAt = now.Add(ttl)
+	}
 	f.rg[roomID] = g
 	f.rs[roomID] = &room
+	if _, ok := f.lobbies[opts.Lobby]; !ok {
+		f.lobbies[opts.Lobby] = make(map[quark.RoomID]struct{})
+	}
+	f.lobbies[opts.Lobby][roomID] = struct{}{}
+	if roomName != "" {
+		f.names[roomName] = roomID
+	}
 
 	ev := RoomAllocatedEvent{
 		GameServer: g.addr,
 		Room:       room,
+		TeamCount:  opts.TeamCount,
+		UserTeams:  opts.UserTeams,
 	}
 	for c := range f.allocListeners {
-		c <- ev
+		select {
+		case c <- ev:
+		default:
+			delete(f.allocListeners, c)
+			close(c)
+		}
 	}
+	f.publishRoomEvent(RoomEvent{Type: RoomAdded, Room: room})
 
 	return g.addr, nil
 }
 
+// RemoveRoom removes the closed room and releases its name
+func (f *Fleet) RemoveRoom(roomID quark.RoomID) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return f.removeRoom(roomID)
+}
+
+func (f *Fleet) removeRoom(roomID quark.RoomID) error {
+	room, ok := f.rs[roomID]
+	if !ok {
+		return ErrRoomStatusNotFound
+	}
+	if g, ok := f.rg[roomID]; ok {
+		g.RemoveRoom(roomID)
+	}
+	if room.RoomName != "" && f.names[room.RoomName] == roomID {
+		delete(f.names, room.RoomName)
+	}
+	delete(f.rs, roomID)
+	delete(f.rg, roomID)
+	delete(f.backfills, roomID)
+	delete(f.lobbies[room.Lobby], roomID)
+	if len(f.lobbies[room.Lobby]) == 0 {
+		delete(f.lobbies, room.Lobby)
+	}
+
+	f.publishRoomEvent(RoomEvent{Type: RoomRemoved, Room: *room})
+	return nil
+}
+
+// LookupGameServerID returns the game server the room is allocated on
+func (f *Fleet) LookupGameServerID(roomID quark.RoomID) (GameServerID, bool) {
+	f.mux.RLock()
+	defer f.mux.RUnlock()
+	g, ok := f.rg[roomID]
+	if !ok {
+		return "", false
+	}
+	return g.id, true
+}
+
 func (f *Fleet) LookupGameServerAddr(roomID quark.RoomID) (GameServerAddr, bool) {
 	f.mux.RLock()
 	defer f.mux.RUnlock()
 	g, ok := f.rg[roomID]
-	return g.addr, ok
+	if !ok {
+		return GameServerAddr{}, false
+	}
+	return g.addr, true
 }
 
+// FindRandomRoom returns a room chosen at random from the open, visible,
+// unlocked and non-full rooms in the lobby of the filter
+func (f *Fleet) FindRandomRoom(filter RoomFilter) (RoomStatus, GameServerAddr, error) {
+	f.mux.RLock()
+	defer f.mux.RUnlock()
+
+	candidates := make([]*RoomStatus, 0)
+	for roomID := range f.lobbies[filter.Lobby] {
+		r := f.rs[roomID]
+		if r.Open && r.Visible && !r.Locked && !r.IsFull() && filter.Match(r) {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		return RoomStatus{}, GameServerAddr{}, ErrRoomNotFound
+	}
+	r := candidates[rand.Intn(len(candidates))]
+	return *r, f.rg[r.RoomID].addr, nil
+}
+
+// UpdateRoomStatus updates the actor count reported by the game server;
+// the other fields are kept from the allocation, see SetRoomFlags for the flags
 func (f *Fleet) UpdateRoomStatus(status RoomStatus) error {
 	f.mux.Lock()
 	defer f.mux.Unlock()
 	roomID := status.RoomID
 
-	_, ok := f.rs[roomID]
+	cur, ok := f.rs[roomID]
 	if !ok {
 		return ErrRoomStatusNotFound
 	}
-	f.rs[roomID] = &status
+	updated := *cur
+	updated.ActorCount = status.ActorCount
+	// the game server knows which reserved seats are left; the expiry is kept
+	updated.ReservedUserIDs = status.ReservedUserIDs
+	if err := f.updateRoomStatus(updated); err != nil {
+		return err
+	}
+	if cur.ActorCount != updated.ActorCount || len(cur.ReservedUserIDs) != len(updated.ReservedUserIDs) {
+		f.publishRoomEvent(RoomEvent{Type: RoomUpdated, Room: updated})
+	}
+	return nil
+}
+
+// SetRoomFlags changes the flags of the room which are not nil.
+func (f *Fleet) SetRoomFlags(roomID quark.RoomID, flags RoomFlags) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	cur, ok := f.rs[roomID]
+	if !ok {
+		return ErrRoomStatusNotFound
+	}
+	updated := *cur
+	if flags.Open != nil {
+		updated.Open = *flags.Open
+	}
+	if flags.Visible != nil {
+		updated.Visible = *flags.Visible
+	}
+	if flags.Locked != nil {
+		updated.Locked = *flags.Locked
+	}
+	if err := f.updateRoomStatus(updated); err != nil {
+		return err
+	}
+	if cur.Open != updated.Open || cur.Visible != updated.Visible || cur.Locked != updated.Locked {
+		f.publishRoomEvent(RoomEvent{Type: RoomUpdated, Room: updated})
+	}
+	return nil
+}
 
+func (f *Fleet) updateRoomStatus(status RoomStatus) error {
+	roomID := status.RoomID
 	gs, ok := f.rg[roomID]
 	if !ok {
 		return errors.New("game server not found")
 	}
+	f.rs[roomID] = &status
 	gs.UpdateRoomStatus(status)
 
 	sort.SliceStable(f.g, func(i, j int) bool {
@@ -162,9 +417,43 @@ func (f *Fleet) RoomList() []RoomStatus {
 	f.mux.RLock()
 	defer f.mux.RUnlock()
 
-	rs := make([]RoomStatus, len(f.rs))
-	for i, r := range f.rs {
-		rs[i] = *r
+	return f.roomList()
+}
+
+func (f *Fleet) roomList() []RoomStatus {
+	rs := make([]RoomStatus, 0, len(f.rs))
+	for _, r := range f.rs {
+		rs = append(rs, *r)
 	}
 	return rs
 }
+
+func (f *Fleet) lobbyRoomList(lobby string) []RoomStatus {
+	ids := f.lobbies[lobby]
+	rs := make([]RoomStatus, 0, len(ids))
+	for roomID := range ids {
+		rs = append(rs, *f.rs[roomID])
+	}
+	return rs
+}
+
+// QueryRooms returns the visible rooms in the lobby of the query matching it
+func (f *Fleet) QueryRooms(q RoomQuery) []RoomStatus {
+	f.mux.RLock()
+	defer f.mux.RUnlock()
+
+	return q.Apply(f.lobbyRoomList(q.Lobby))
+}
+
+// Lobbies returns the names of the lobbies with rooms
+func (f *Fleet) Lobbies() []string {
+	f.mux.RLock()
+	defer f.mux.RUnlock()
+
+	lobbies := make([]string, 0, len(f.lobbies))
+	for lobby := range f.lobbies {
+		lobbies = append(lobbies, lobby)
+	}
+	sort.Strings(lobbies)
+	return lobbies
+}
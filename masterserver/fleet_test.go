@@ -2,9 +2,12 @@ package masterserver
 
 import (
 	"math/rand"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
 	"quark"
 )
@@ -21,21 +24,21 @@ func TestFleet_AllocateRoom(t *testing.T) {
 	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "30000"}, 3)
 
 	r1 := quark.RoomID(rand.Uint64())
-	alloc1, err := fleet.AllocateRoom(r1, "")
+	alloc1, err := fleet.AllocateRoom(r1, "", RoomOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, addr1, alloc1)
 
 	r2 := quark.RoomID(rand.Uint64())
-	alloc2, err := fleet.AllocateRoom(r2, "")
+	alloc2, err := fleet.AllocateRoom(r2, "", RoomOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, addr2, alloc2)
 
 	r3 := quark.RoomID(rand.Uint64())
-	alloc3, err := fleet.AllocateRoom(r3, "")
+	alloc3, err := fleet.AllocateRoom(r3, "", RoomOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -71,14 +74,14 @@ func TestFleet_UpdateRoomStatus(t *testing.T) {
 	fleet.RegisterGameServer(addr3, 3)
 
 	r1 := quark.RoomID(rand.Uint64())
-	alloc1, err := fleet.AllocateRoom(r1, "")
+	alloc1, err := fleet.AllocateRoom(r1, "", RoomOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, addr1, alloc1)
 
 	r2 := quark.RoomID(rand.Uint64())
-	alloc2, err := fleet.AllocateRoom(r2, "")
+	alloc2, err := fleet.AllocateRoom(r2, "", RoomOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -87,9 +90,320 @@ func TestFleet_UpdateRoomStatus(t *testing.T) {
 	fleet.UpdateRoomStatus(RoomStatus{RoomID: r2, ActorCount: 2})
 
 	r3 := quark.RoomID(rand.Uint64())
-	alloc3, err := fleet.AllocateRoom(r3, "")
+	alloc3, err := fleet.AllocateRoom(r3, "", RoomOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, addr3, alloc3)
 }
+
+func TestFleet_FindRandomRoom(t *testing.T) {
+	fleet := NewFleet()
+
+	_, _, err := fleet.FindRandomRoom(RoomFilter{})
+	assert.Equal(t, ErrRoomNotFound, err)
+
+	addr := GameServerAddr{"127.0.0.1", "10000"}
+	fleet.RegisterGameServer(addr, 10)
+
+	full := quark.RoomID(rand.Uint64())
+	_, err = fleet.AllocateRoom(full, "ctf-1", RoomOptions{MaxActors: 2, Properties: map[string]string{"mode": "ctf"}, LobbyProperties: []string{"mode"}})
+	assert.NoError(t, err)
+	assert.NoError(t, fleet.UpdateRoomStatus(RoomStatus{RoomID: full, ActorCount: 2}))
+
+	ctf := quark.RoomID(rand.Uint64())
+	_, err = fleet.AllocateRoom(ctf, "ctf-2", RoomOptions{MaxActors: 2, Properties: map[string]string{"mode": "ctf"}, LobbyProperties: []string{"mode"}})
+	assert.NoError(t, err)
+	assert.NoError(t, fleet.UpdateRoomStatus(RoomStatus{RoomID: ctf, ActorCount: 1}))
+
+	dm := quark.RoomID(rand.Uint64())
+	_, err = fleet.AllocateRoom(dm, "dm-1", RoomOptions{Properties: map[string]string{"mode": "dm"}, LobbyProperties: []string{"mode"}})
+	assert.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		r, a, err := fleet.FindRandomRoom(RoomFilter{Properties: map[string]string{"mode": "ctf"}})
+		assert.NoError(t, err)
+		assert.Equal(t, ctf, r.RoomID)
+		assert.Equal(t, "ctf-2", r.RoomName)
+		assert.Equal(t, addr, a)
+	}
+
+	r, _, err := fleet.FindRandomRoom(RoomFilter{NamePrefix: "dm-"})
+	assert.NoError(t, err)
+	assert.Equal(t, dm, r.RoomID)
+
+	r, _, err = fleet.FindRandomRoom(RoomFilter{MinActors: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, ctf, r.RoomID)
+
+	_, _, err = fleet.FindRandomRoom(RoomFilter{NamePrefix: "dm-", MinActors: 1})
+	assert.Equal(t, ErrRoomNotFound, err)
+}
+
+func TestFleet_ReservedSeats(t *testing.T) {
+	fleet := NewFleet()
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 10)
+
+	r1 := quark.RoomID(rand.Uint64())
+	_, err := fleet.AllocateRoom(r1, "r1", RoomOptions{MaxActors: 2, ReservedUserIDs: []string{"alice", "bob"}})
+	assert.NoError(t, err)
+	assert.NoError(t, fleet.UpdateRoomStatus(RoomStatus{RoomID: r1, ReservedUserIDs: []string{"alice", "bob"}}))
+
+	_, _, err = fleet.FindRandomRoom(RoomFilter{})
+	assert.Equal(t, ErrRoomNotFound, err)
+
+	// bob has joined and alice has released her seat
+	assert.NoError(t, fleet.UpdateRoomStatus(RoomStatus{RoomID: r1, ActorCount: 1}))
+	r, _, err := fleet.FindRandomRoom(RoomFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, r1, r.RoomID)
+	assert.Equal(t, uint(0), r.ReservedSeats(time.Now()))
+
+	reserved := make(chan SeatsReservedEvent, 1)
+	fleet.AddSeatReservationListener(reserved)
+	_, err = fleet.ReserveSeats(r1, []string{"dave"}, nil, time.Minute)
+	assert.NoError(t, err)
+	// renewing a seat takes no more room
+	_, err = fleet.ReserveSeats(r1, []string{"dave"}, nil, time.Minute)
+	assert.NoError(t, err)
+	_, err = fleet.ReserveSeats(r1, []string{"erin"}, nil, time.Minute)
+	assert.Equal(t, ErrRoomFull, err)
+	_, err = fleet.ReserveSeats(quark.RoomID(rand.Uint64()), []string{"erin"}, nil, time.Minute)
+	assert.Equal(t, ErrRoomStatusNotFound, err)
+
+	// the listener fell behind on the renewal and is closed
+	ev := <-reserved
+	assert.Equal(t, []string{"dave"}, ev.UserIDs)
+	_, ok := <-reserved
+	assert.False(t, ok)
+	fleet.RemoveSeatReservationListener(reserved)
+
+	r2 := quark.RoomID(rand.Uint64())
+	_, err = fleet.AllocateRoom(r2, "r2", RoomOptions{MaxActors: 1, ReservedUserIDs: []string{"carol"}, ReservationTTL: 50 * time.Millisecond})
+	assert.NoError(t, err)
+	_, _, err = fleet.FindRandomRoom(RoomFilter{NamePrefix: "r2"})
+	assert.Equal(t, ErrRoomNotFound, err)
+
+	time.Sleep(100 * time.Millisecond)
+	r, _, err = fleet.FindRandomRoom(RoomFilter{NamePrefix: "r2"})
+	assert.NoError(t, err)
+	assert.Equal(t, r2, r.RoomID)
+}
+
+func TestFleet_LoadOrAllocateRoom(t *testing.T) {
+	fleet := NewFleet()
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 1)
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "20000"}, 100)
+
+	var wg sync.WaitGroup
+	ids := make([]quark.RoomID, 32)
+	created := atomic.NewInt32(0)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			roomID, _, loaded, err := fleet.LoadOrAllocateRoom("lobby", RoomOptions{})
+			assert.NoError(t, err)
+			if !loaded {
+				created.Inc()
+			}
+			ids[i] = roomID
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), created.Load())
+	for _, id := range ids {
+		assert.Equal(t, ids[0], id)
+	}
+	assert.Len(t, fleet.RoomList(), 1)
+
+	_, err := fleet.AllocateRoom(quark.NewRoomID(), "lobby", RoomOptions{})
+	assert.Equal(t, ErrRoomNameTaken, err)
+
+	_, _, _, err = fleet.LoadOrAllocateRoom("", RoomOptions{})
+	assert.Equal(t, ErrRoomNameRequired, err)
+}
+
+func TestFleet_SubscribeRoomList(t *testing.T) {
+	fleet := NewFleet()
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 1000)
+
+	r1 := quark.RoomID(rand.Uint64())
+	_, err := fleet.AllocateRoom(r1, "r1", RoomOptions{})
+	assert.NoError(t, err)
+
+	rooms, c := fleet.SubscribeRoomList("")
+	defer fleet.UnsubscribeRoomList(c)
+	assert.Len(t, rooms, 1)
+
+	r2 := quark.RoomID(rand.Uint64())
+	_, err = fleet.AllocateRoom(r2, "r2", RoomOptions{})
+	assert.NoError(t, err)
+	ev := <-c
+	assert.Equal(t, RoomAdded, ev.Type)
+	assert.Equal(t, r2, ev.Room.RoomID)
+
+	assert.NoError(t, fleet.UpdateRoomStatus(RoomStatus{RoomID: r1, ActorCount: 3}))
+	ev = <-c
+	assert.Equal(t, RoomUpdated, ev.Type)
+	assert.Equal(t, uint(3), ev.Room.ActorCount)
+	assert.Equal(t, "r1", ev.Room.RoomName)
+
+	assert.NoError(t, fleet.RemoveRoom(r1))
+	ev = <-c
+	assert.Equal(t, RoomRemoved, ev.Type)
+	assert.Equal(t, r1, ev.Room.RoomID)
+	assert.Equal(t, ErrRoomStatusNotFound, fleet.RemoveRoom(r1))
+
+	// the name is released
+	_, err = fleet.AllocateRoom(quark.RoomID(rand.Uint64()), "r1", RoomOptions{})
+	assert.NoError(t, err)
+	<-c
+
+	// falling behind closes the channel
+	for i := 0; i <= roomEventBufferSize; i++ {
+		_, err := fleet.AllocateRoom(quark.RoomID(rand.Uint64()), "", RoomOptions{})
+		assert.NoError(t, err)
+	}
+	n := 0
+	for range c {
+		n++
+	}
+	assert.Equal(t, roomEventBufferSize, n)
+}
+
+func TestFleet_RoomMetadata(t *testing.T) {
+	fleet := NewFleet()
+	fleet.RegisterGameServerInRegion(GameServerAddr{"127.0.0.1", "10000"}, "us-east", 10)
+	tokyo := GameServerAddr{"127.0.0.1", "20000"}
+	fleet.RegisterGameServerInRegion(tokyo, "ap-northeast", 10)
+
+	r1 := quark.RoomID(rand.Uint64())
+	addr, err := fleet.AllocateRoom(r1, "r1", RoomOptions{
+		Region:          "ap-northeast",
+		GameMode:        "ctf",
+		Locked:          true,
+		Properties:      map[string]string{"map": "forest", "password": "x"},
+		LobbyProperties: []string{"map", "level"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, tokyo, addr)
+
+	_, err = fleet.AllocateRoom(quark.RoomID(rand.Uint64()), "r2", RoomOptions{Region: "eu-west"})
+	assert.Equal(t, ErrNotEnoughGameServers, err)
+
+	rooms := fleet.RoomList()
+	assert.Len(t, rooms, 1)
+	r := rooms[0]
+	assert.Equal(t, "ap-northeast", r.Region)
+	assert.Equal(t, "ctf", r.GameMode)
+	assert.True(t, r.Open)
+	assert.True(t, r.Visible)
+	assert.True(t, r.Locked)
+	assert.False(t, r.CreatedAt.IsZero())
+	assert.Equal(t, map[string]string{"map": "forest"}, r.Properties)
+
+	// locked rooms are not joined at random
+	_, _, err = fleet.FindRandomRoom(RoomFilter{})
+	assert.Equal(t, ErrRoomNotFound, err)
+
+	unlocked, closed := false, false
+	assert.NoError(t, fleet.SetRoomFlags(r1, RoomFlags{Locked: &unlocked}))
+	r, _, err = fleet.FindRandomRoom(RoomFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ctf", r.GameMode)
+
+	// the flags are kept when the game server reports the actor count
+	assert.NoError(t, fleet.UpdateRoomStatus(RoomStatus{RoomID: r1, ActorCount: 1}))
+	r, _, err = fleet.FindRandomRoom(RoomFilter{})
+	assert.NoError(t, err)
+	assert.True(t, r.Open)
+	assert.True(t, r.Visible)
+
+	assert.NoError(t, fleet.SetRoomFlags(r1, RoomFlags{Open: &closed}))
+	_, _, err = fleet.FindRandomRoom(RoomFilter{})
+	assert.Equal(t, ErrRoomNotFound, err)
+	assert.True(t, fleet.RoomList()[0].Visible)
+}
+
+func TestFleet_Lobbies(t *testing.T) {
+	fleet := NewFleet()
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 100)
+
+	_, ranked := fleet.SubscribeRoomList("ranked")
+	defer fleet.UnsubscribeRoomList(ranked)
+	_, casual := fleet.SubscribeRoomList("")
+	defer fleet.UnsubscribeRoomList(casual)
+
+	r1 := quark.RoomID(rand.Uint64())
+	_, err := fleet.AllocateRoom(r1, "r1", RoomOptions{Lobby: "ranked"})
+	assert.NoError(t, err)
+	r2 := quark.RoomID(rand.Uint64())
+	_, err = fleet.AllocateRoom(r2, "r2", RoomOptions{})
+	assert.NoError(t, err)
+
+	ev := <-ranked
+	assert.Equal(t, r1, ev.Room.RoomID)
+	assert.Equal(t, "ranked", ev.Room.Lobby)
+	ev = <-casual
+	assert.Equal(t, r2, ev.Room.RoomID)
+	assert.Len(t, ranked, 0)
+
+	rooms := fleet.QueryRooms(RoomQuery{Lobby: "ranked"})
+	assert.Len(t, rooms, 1)
+	assert.Equal(t, r1, rooms[0].RoomID)
+
+	r, _, err := fleet.FindRandomRoom(RoomFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, r2, r.RoomID)
+	_, _, err = fleet.FindRandomRoom(RoomFilter{Lobby: "other"})
+	assert.Equal(t, ErrRoomNotFound, err)
+
+	assert.Equal(t, []string{"", "ranked"}, fleet.Lobbies())
+	assert.NoError(t, fleet.RemoveRoom(r1))
+	assert.Equal(t, []string{""}, fleet.Lobbies())
+}
+
+func TestFleet_Stats(t *testing.T) {
+	fleet := NewFleet()
+	g1 := fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 2)
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "20000"}, 3)
+
+	r1 := quark.RoomID(rand.Uint64())
+	_, err := fleet.AllocateRoom(r1, "", RoomOptions{Lobby: "ranked"})
+	assert.NoError(t, err)
+	assert.NoError(t, fleet.UpdateRoomStatus(RoomStatus{RoomID: r1, ActorCount: 4}))
+	r2 := quark.RoomID(rand.Uint64())
+	_, err = fleet.AllocateRoom(r2, "", RoomOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, fleet.UpdateRoomStatus(RoomStatus{RoomID: r2, ActorCount: 1}))
+
+	_, c1 := fleet.SubscribeRoomList("")
+	defer fleet.UnsubscribeRoomList(c1)
+	_, c2 := fleet.SubscribeRoomList("idle")
+	defer fleet.UnsubscribeRoomList(c2)
+
+	stats := fleet.Stats()
+	assert.Equal(t, []LobbyStats{
+		{Lobby: "", Rooms: 1, ActorsInRooms: 1, ActorsInLobby: 1},
+		{Lobby: "idle", ActorsInLobby: 1},
+		{Lobby: "ranked", Rooms: 1, ActorsInRooms: 4},
+	}, stats.Lobbies)
+	assert.Equal(t, uint(2), stats.Rooms)
+	assert.Equal(t, uint(5), stats.ActorsInRooms)
+	assert.Equal(t, uint(2), stats.ActorsInLobbies)
+	assert.Equal(t, uint(3), stats.FreeRoomCapacity)
+	assert.Len(t, stats.GameServers, 2)
+
+	// rooms of a game server go away with it
+	addr, _ := fleet.LookupGameServerAddr(r1)
+	assert.Equal(t, "10000", addr.Port)
+	fleet.UnregisterGameServer(g1)
+	stats = fleet.Stats()
+	assert.Len(t, stats.GameServers, 1)
+	assert.Equal(t, uint(2), stats.FreeRoomCapacity)
+	assert.Equal(t, uint(1), stats.Rooms)
+	_, ok := fleet.LookupGameServerAddr(r1)
+	assert.False(t, ok)
+}
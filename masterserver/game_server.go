@@ -9,20 +9,35 @@ import (
 type GameServer struct {
 	id      GameServerID
 	addr    GameServerAddr
+	region  string
 	rooms   map[quark.RoomID]*RoomStatus
 	nActors uint
 	roomCap uint
 	mux     sync.RWMutex
 }
 
-func newGameServer(id GameServerID, addr GameServerAddr, roomCap uint) *GameServer {
-	return &GameServer{id: id, addr: addr, rooms: make(map[quark.RoomID]*RoomStatus), roomCap: roomCap}
+func newGameServer(id GameServerID, addr GameServerAddr, region string, roomCap uint) *GameServer {
+	return &GameServer{id: id, addr: addr, region: region, rooms: make(map[quark.RoomID]*RoomStatus), roomCap: roomCap}
 }
 
 func (g *GameServer) Cap() uint {
 	return g.roomCap - uint(len(g.rooms))
 }
 
+func (g *GameServer) Stats() GameServerStats {
+	g.mux.RLock()
+	defer g.mux.RUnlock()
+
+	return GameServerStats{
+		ID:           g.id,
+		Addr:         g.addr,
+		Region:       g.region,
+		Rooms:        uint(len(g.rooms)),
+		Actors:       g.nActors,
+		FreeCapacity: g.roomCap - uint(len(g.rooms)),
+	}
+}
+
 func (g *GameServer) HasCapacity() bool {
 	g.mux.RLock()
 	defer g.mux.RUnlock()
@@ -41,6 +56,18 @@ func (g *GameServer) AddRoom(roomID quark.RoomID) error {
 	return nil
 }
 
+func (g *GameServer) RemoveRoom(roomID quark.RoomID) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+
+	r, ok := g.rooms[roomID]
+	if !ok {
+		return
+	}
+	g.nActors -= r.ActorCount
+	delete(g.rooms, roomID)
+}
+
 func (g *GameServer) UpdateRoomStatus(status RoomStatus) error {
 	g.mux.Lock()
 	defer g.mux.Unlock()
@@ -0,0 +1,634 @@
+package masterserver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quark"
+)
+
+var (
+	ErrUnknownMode       = errors.New("no match rules for the mode")
+	ErrInvalidTicket     = errors.New("invalid ticket")
+	ErrTicketNotFound    = errors.New("ticket not found")
+	ErrTicketMatched     = errors.New("ticket is already matched")
+	ErrMatchmakerStopped = errors.New("matchmaker stopped")
+	ErrInvalidMatchRules = errors.New("invalid match rules")
+)
+
+// MatchRules are the rules forming the matches of a mode
+type MatchRules struct {
+	// PlayersPerMatch is the number of players in a match, a multiple of TeamCount
+	PlayersPerMatch int
+	// TeamCount splits the players into teams of equal size; 0 or 1 means no teams
+	TeamCount int
+
+	// SkillWindow is the max difference of skill ratings in a match when the
+	// tickets are enqueued. It widens by SkillWindowGrowth per second of
+	// waiting, up to MaxSkillWindow if it is not 0.
+	SkillWindow       float64
+	SkillWindowGrowth float64
+	MaxSkillWindow    float64
+
+	// MaxLatencyMillis excludes the regions with higher latency of tickets
+	// without a region; 0 means no limit
+	MaxLatencyMillis uint32
+}
+
+func (r *MatchRules) validate() error {
+	switch {
+	case r.PlayersPerMatch <= 0:
+		return errors.New("PlayersPerMatch must be positive")
+	case r.TeamCount < 0:
+		return errors.New("TeamCount must not be negative")
+	case 1 < r.TeamCount && r.PlayersPerMatch%r.TeamCount != 0:
+		return errors.New("PlayersPerMatch must be divisible by TeamCount")
+	}
+	return nil
+}
+
+func (r *MatchRules) skillWindow(wait time.Duration) float64 {
+	w := r.SkillWindow + r.SkillWindowGrowth*wait.Seconds()
+	if 0 < r.MaxSkillWindow && r.MaxSkillWindow < w {
+		return r.MaxSkillWindow
+	}
+	return w
+}
+
+func (r *MatchRules) teamSize() int {
+	if r.TeamCount <= 1 {
+		return r.PlayersPerMatch
+	}
+	return r.PlayersPerMatch / r.TeamCount
+}
+
+type TicketID string
+
+// Ticket is a player, or a party of players matched together
+type Ticket struct {
+	UserIDs     []string
+	Mode        string
+	SkillRating float64
+	// Region is empty to choose the region by LatencyMillis
+	Region        string
+	LatencyMillis map[string]uint32
+}
+
+type Seat struct {
+	UserID string
+	// Team is NoTeam if the mode has no teams, otherwise from 1
+	Team uint32
+}
+
+const NoTeam uint32 = 0
+
+// Match is the room allocated for the matched tickets
+type Match struct {
+	RoomID     quark.RoomID
+	GameServer GameServerAddr
+	Mode       string
+	Region     string
+	Seats      []Seat
+	// Backfill is true if the room is running, joined to fill the seats left
+	Backfill bool
+}
+
+type MatchmakerOptions struct {
+	// Rules are by mode
+	Rules map[string]MatchRules
+	// Interval is the interval of forming matches, DefaultMatchInterval if 0
+	Interval time.Duration
+	// ReservationTTL releases the seats of matched users who have not joined;
+	// DefaultReservationTTL is used if 0
+	ReservationTTL time.Duration
+}
+
+type queuedTicket struct {
+	Ticket
+	id         TicketID
+	enqueuedAt time.Time
+	c          chan Match
+	// canceled while its match is allocated
+	canceled bool
+}
+
+// Matchmaker forms matches from the queued tickets and allocates their rooms in the fleet.
+type Matchmaker struct {
+	fleet *Fleet
+	opts  MatchmakerOptions
+
+	mu    sync.Mutex
+	queue []*queuedTicket
+	// users are the users of the queued tickets and of the matches being allocated
+	users map[string]bool
+	// allocating are the tickets of the matches being allocated
+	allocating map[TicketID]*queuedTicket
+	stopped    bool
+
+	done chan struct{}
+	once sync.Once
+}
+
+const DefaultMatchInterval = time.Second
+
+// NewMatchmaker returns an error wrapping ErrInvalidMatchRules if the rules of a mode can never form a match.
+func NewMatchmaker(fleet *Fleet, opts MatchmakerOptions) (*Matchmaker, error) {
+	for mode, rules := range opts.Rules {
+		if err := rules.validate(); err != nil {
+			return nil, fmt.Errorf("%w of mode %q: %v", ErrInvalidMatchRules, mode, err)
+		}
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultMatchInterval
+	}
+	m := &Matchmaker{
+		fleet:      fleet,
+		opts:       opts,
+		users:      make(map[string]bool),
+		allocating: make(map[TicketID]*queuedTicket),
+		done:       make(chan struct{}),
+	}
+	go m.loop()
+	return m, nil
+}
+
+func (m *Matchmaker) loop() {
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case now := <-ticker.C:
+			m.match(now)
+		}
+	}
+}
+
+// Stop stops forming matches; the channels of the queued tickets are closed.
+func (m *Matchmaker) Stop() {
+	m.once.Do(func() {
+		close(m.done)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.stopped = true
+		for _, t := range m.queue {
+			close(t.c)
+		}
+		m.queue = nil
+		m.users = make(map[string]bool)
+	})
+}
+
+// Enqueue queues the ticket. The returned channel receives the match of the
+// ticket and is closed after it, or when the ticket is canceled.
+// A user can only be in one ticket at a time.
+func (m *Matchmaker) Enqueue(t Ticket) (TicketID, <-chan Match, error) {
+	rules, ok := m.opts.Rules[t.Mode]
+	if !ok {
+		return "", nil, ErrUnknownMode
+	}
+	if len(t.UserIDs) == 0 || rules.teamSize() < len(t.UserIDs) {
+		return "", nil, ErrInvalidTicket
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stopped {
+		return "", nil, ErrMatchmakerStopped
+	}
+	users := make(map[string]bool, len(t.UserIDs))
+	for _, u := range t.UserIDs {
+		if users[u] || m.users[u] {
+			return "", nil, ErrInvalidTicket
+		}
+		users[u] = true
+	}
+	for u := range users {
+		m.users[u] = true
+	}
+	q := &queuedTicket{
+		Ticket:     t,
+		id:         TicketID(uuid.Must(uuid.NewRandom()).String()),
+		enqueuedAt: time.Now(),
+		c:          make(chan Match, 1),
+	}
+	m.queue = append(m.queue, q)
+	return q.id, q.c, nil
+}
+
+// Cancel removes the queued ticket and closes its channel. It is too late to
+// cancel a ticket whose match is being allocated: ErrTicketMatched is returned,
+// the ticket still gets the match if the allocation succeeds, and its seat is
+// released after the ReservationTTL. The ticket is not queued again if the
+// allocation fails.
+func (m *Matchmaker) Cancel(id TicketID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, t := range m.queue {
+		if t.id == id {
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			m.release(t)
+			close(t.c)
+			return nil
+		}
+	}
+	if t, ok := m.allocating[id]; ok {
+		t.canceled = true
+		return ErrTicketMatched
+	}
+	return ErrTicketNotFound
+}
+
+// QueueLen returns the number of queued tickets
+func (m *Matchmaker) QueueLen() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.queue)
+}
+
+// pendingMatch is a match formed from the queue whose room is not allocated yet
+type pendingMatch struct {
+	group []*queuedTicket
+	match Match
+	// opts are the options of the new room; unused for a backfill
+	opts RoomOptions
+}
+
+func (p *pendingMatch) userIDs() []string {
+	userIDs := make([]string, len(p.match.Seats))
+	for i, seat := range p.match.Seats {
+		userIDs[i] = seat.UserID
+	}
+	return userIDs
+}
+
+// userTeams returns the teams of the seats, or nil if the match has no teams
+func (p *pendingMatch) userTeams() map[string]uint32 {
+	var teams map[string]uint32
+	for _, seat := range p.match.Seats {
+		if seat.Team == NoTeam {
+			continue
+		}
+		if teams == nil {
+			teams = make(map[string]uint32, len(p.match.Seats))
+		}
+		teams[seat.UserID] = seat.Team
+	}
+	return teams
+}
+
+// match fills the backfills of running rooms, then forms the matches of the
+// queued tickets, the oldest first. The rooms are allocated without holding
+// the lock; the tickets of a failed allocation are queued again.
+func (m *Matchmaker) match(now time.Time) {
+	pending := m.take(now)
+	allocated := make([]bool, len(pending))
+	for i := range pending {
+		allocated[i] = m.allocate(&pending[i]) == nil
+	}
+	m.finish(pending, allocated)
+}
+
+// take forms the matches and marks their tickets as allocating
+func (m *Matchmaker) take(now time.Time) []pendingMatch {
+	backfills := m.fleet.Backfills()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending := m.formMatches(backfills, now)
+	for _, p := range pending {
+		for _, t := range p.group {
+			m.allocating[t.id] = t
+		}
+	}
+	return pending
+}
+
+// finish delivers the allocated matches and queues the tickets of the others again
+func (m *Matchmaker) finish(pending []pendingMatch, allocated []bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var failed []*queuedTicket
+	for i, p := range pending {
+		for _, t := range p.group {
+			delete(m.allocating, t.id)
+			if !allocated[i] && !m.stopped && !t.canceled {
+				// retried at the next interval
+				failed = append(failed, t)
+				continue
+			}
+			m.release(t)
+			if allocated[i] {
+				t.c <- p.match
+			}
+			close(t.c)
+		}
+	}
+	if len(failed) != 0 {
+		m.queue = append(m.queue, failed...)
+		sort.SliceStable(m.queue, func(i, j int) bool {
+			return m.queue[i].enqueuedAt.Before(m.queue[j].enqueuedAt)
+		})
+	}
+}
+
+// formMatches takes the tickets of the matches it forms out of the queue
+func (m *Matchmaker) formMatches(backfills []Backfill, now time.Time) []pendingMatch {
+	var pending []pendingMatch
+	matched := make(map[TicketID]bool)
+	for _, b := range backfills {
+		if p, ok := m.backfill(b, now, matched); ok {
+			pending = append(pending, p)
+		}
+	}
+	for _, anchor := range m.queue {
+		if matched[anchor.id] {
+			continue
+		}
+		rules := m.opts.Rules[anchor.Mode]
+		group, region, ok := m.formGroup(anchor, &rules, now, matched)
+		if !ok {
+			continue
+		}
+		seats, ok := balanceTeams(group, &rules)
+		if !ok {
+			continue
+		}
+		for _, t := range group {
+			matched[t.id] = true
+		}
+
+		p := pendingMatch{
+			group: group,
+			match: Match{RoomID: quark.NewRoomID(), Mode: anchor.Mode, Region: region, Seats: seats},
+			opts: RoomOptions{
+				MaxActors:      uint(rules.PlayersPerMatch),
+				GameMode:       anchor.Mode,
+				Region:         region,
+				Hidden:         true,
+				ReservationTTL: m.opts.ReservationTTL,
+			},
+		}
+		p.opts.ReservedUserIDs = p.userIDs()
+		if 1 < rules.TeamCount {
+			p.opts.TeamCount = uint(rules.TeamCount)
+			p.opts.UserTeams = p.userTeams()
+		}
+		pending = append(pending, p)
+	}
+
+	queue := m.queue[:0]
+	for _, t := range m.queue {
+		if !matched[t.id] {
+			queue = append(queue, t)
+		}
+	}
+	m.queue = queue
+	return pending
+}
+
+// allocate allocates the room of the match, or reserves the seats of a backfill
+func (m *Matchmaker) allocate(p *pendingMatch) error {
+	var err error
+	if p.match.Backfill {
+		p.match.GameServer, err = m.fleet.ReserveSeats(p.match.RoomID, p.userIDs(), p.userTeams(), m.opts.ReservationTTL)
+	} else {
+		p.match.GameServer, err = m.fleet.AllocateRoom(p.match.RoomID, "", p.opts)
+	}
+	return err
+}
+
+// release frees the users of the ticket to be queued again
+func (m *Matchmaker) release(t *queuedTicket) {
+	for _, u := range t.UserIDs {
+		delete(m.users, u)
+	}
+}
+
+// backfill collects the queued tickets fitting the backfill into its room
+func (m *Matchmaker) backfill(b Backfill, now time.Time, matched map[TicketID]bool) (pendingMatch, bool) {
+	rules, ok := m.opts.Rules[b.Mode]
+	if !ok {
+		return pendingMatch{}, false
+	}
+
+	var group []*queuedTicket
+	var seats []Seat
+	for _, t := range m.queue {
+		if matched[t.id] || t.Mode != b.Mode || b.Slots < uint(len(seats)+len(t.UserIDs)) {
+			continue
+		}
+		if rules.skillWindow(now.Sub(t.enqueuedAt)) < math.Abs(t.SkillRating-b.SkillRating) {
+			continue
+		}
+		if regions := allowedRegions(&t.Ticket, &rules); regions != nil && !regions[b.Region] {
+			continue
+		}
+		group = append(group, t)
+		for _, u := range t.UserIDs {
+			seats = append(seats, Seat{UserID: u, Team: b.Team})
+		}
+	}
+	if len(group) == 0 {
+		return pendingMatch{}, false
+	}
+	for _, t := range group {
+		matched[t.id] = true
+	}
+	return pendingMatch{
+		group: group,
+		match: Match{RoomID: b.RoomID, Mode: b.Mode, Region: b.Region, Seats: seats, Backfill: true},
+	}, true
+}
+
+// formGroup collects the tickets matching the anchor, nearest in skill first,
+// until the match is full
+func (m *Matchmaker) formGroup(anchor *queuedTicket, rules *MatchRules, now time.Time, matched map[TicketID]bool) ([]*queuedTicket, string, bool) {
+	candidates := make([]*queuedTicket, 0)
+	for _, t := range m.queue {
+		if t != anchor && !matched[t.id] && t.Mode == anchor.Mode {
+			candidates = append(candidates, t)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return math.Abs(candidates[i].SkillRating-anchor.SkillRating) < math.Abs(candidates[j].SkillRating-anchor.SkillRating)
+	})
+
+	compatible := func(a, b *queuedTicket) bool {
+		w := math.Max(rules.skillWindow(now.Sub(a.enqueuedAt)), rules.skillWindow(now.Sub(b.enqueuedAt)))
+		return math.Abs(a.SkillRating-b.SkillRating) <= w
+	}
+
+	group := []*queuedTicket{anchor}
+	players := len(anchor.UserIDs)
+	regions := allowedRegions(&anchor.Ticket, rules)
+	for _, c := range candidates {
+		if players == rules.PlayersPerMatch {
+			break
+		}
+		if rules.PlayersPerMatch < players+len(c.UserIDs) {
+			continue
+		}
+		ok := true
+		for _, t := range group {
+			if !compatible(t, c) {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		rs := intersectRegions(regions, allowedRegions(&c.Ticket, rules))
+		if rs != nil && len(rs) == 0 {
+			continue
+		}
+		// skip the parties which can not be split into the teams with the group
+		if 1 < rules.TeamCount {
+			if _, ok := balanceTeams(append(group[:len(group):len(group)], c), rules); !ok {
+				continue
+			}
+		}
+		regions = rs
+		group = append(group, c)
+		players += len(c.UserIDs)
+	}
+	if players != rules.PlayersPerMatch {
+		return nil, "", false
+	}
+	return group, bestRegion(group, regions), true
+}
+
+// allowedRegions returns the regions the ticket can play in, or nil for any region
+func allowedRegions(t *Ticket, rules *MatchRules) map[string]bool {
+	if t.Region != "" {
+		return map[string]bool{t.Region: true}
+	}
+	if len(t.LatencyMillis) == 0 {
+		return nil
+	}
+	regions := make(map[string]bool)
+	for r, l := range t.LatencyMillis {
+		if rules.MaxLatencyMillis == 0 || l <= rules.MaxLatencyMillis {
+			regions[r] = true
+		}
+	}
+	return regions
+}
+
+func intersectRegions(a, b map[string]bool) map[string]bool {
+	if a == nil {
+		return b
+	} else if b == nil {
+		return a
+	}
+	regions := make(map[string]bool)
+	for r := range a {
+		if b[r] {
+			regions[r] = true
+		}
+	}
+	return regions
+}
+
+// bestRegion returns the region with the lowest max latency of the group, or
+// empty for any region
+func bestRegion(group []*queuedTicket, regions map[string]bool) string {
+	best, bestLatency := "", uint32(math.MaxUint32)
+	for r := range regions {
+		var worst uint32
+		for _, t := range group {
+			if l := t.LatencyMillis[r]; worst < l {
+				worst = l
+			}
+		}
+		if worst < bestLatency || (worst == bestLatency && r < best) {
+			best, bestLatency = r, worst
+		}
+	}
+	return best
+}
+
+// balanceTeams assigns the tickets to the teams with the lowest total skill,
+// keeping parties together. The tickets may fill the teams partially; if the
+// greedy assignment does not fit the parties, the other assignments are tried.
+func balanceTeams(group []*queuedTicket, rules *MatchRules) ([]Seat, bool) {
+	seats := make([]Seat, 0, rules.PlayersPerMatch)
+	if rules.TeamCount <= 1 {
+		for _, t := range group {
+			for _, u := range t.UserIDs {
+				seats = append(seats, Seat{UserID: u, Team: NoTeam})
+			}
+		}
+		return seats, true
+	}
+
+	sorted := append([]*queuedTicket(nil), group...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if len(sorted[i].UserIDs) != len(sorted[j].UserIDs) {
+			return len(sorted[i].UserIDs) > len(sorted[j].UserIDs)
+		}
+		return sorted[i].SkillRating > sorted[j].SkillRating
+	})
+
+	size := make([]int, rules.TeamCount)
+	skill := make([]float64, rules.TeamCount)
+	teams := make([]int, len(sorted))
+	var assign func(k int) bool
+	assign = func(k int) bool {
+		if k == len(sorted) {
+			return true
+		}
+		t := sorted[k]
+		order := make([]int, 0, len(size))
+		for i := range size {
+			if len(t.UserIDs)+size[i] <= rules.teamSize() {
+				order = append(order, i)
+			}
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return skill[order[i]] < skill[order[j]]
+		})
+		triedEmpty := false
+		for _, i := range order {
+			// empty teams are interchangeable
+			if size[i] == 0 {
+				if triedEmpty {
+					continue
+				}
+				triedEmpty = true
+			}
+			size[i] += len(t.UserIDs)
+			skill[i] += t.SkillRating * float64(len(t.UserIDs))
+			teams[k] = i
+			if assign(k + 1) {
+				return true
+			}
+			size[i] -= len(t.UserIDs)
+			skill[i] -= t.SkillRating * float64(len(t.UserIDs))
+		}
+		return false
+	}
+	if !assign(0) {
+		return nil, false
+	}
+	for k, t := range sorted {
+		for _, u := range t.UserIDs {
+			seats = append(seats, Seat{UserID: u, Team: uint32(teams[k] + 1)})
+		}
+	}
+	return seats, true
+}
@@ -0,0 +1,293 @@
+package masterserver
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"quark"
+)
+
+func newTestMatchmaker(t *testing.T, rules MatchRules) (*Fleet, *Matchmaker) {
+	fleet := NewFleet()
+	m, err := NewMatchmaker(fleet, MatchmakerOptions{
+		Rules:    map[string]MatchRules{"duel": rules},
+		Interval: time.Hour,
+	})
+	require.NoError(t, err)
+	return fleet, m
+}
+
+func TestMatchmaker_Enqueue(t *testing.T) {
+	_, m := newTestMatchmaker(t, MatchRules{PlayersPerMatch: 4, TeamCount: 2})
+	defer m.Stop()
+
+	_, _, err := m.Enqueue(Ticket{UserIDs: []string{"a"}, Mode: "unknown"})
+	assert.Equal(t, ErrUnknownMode, err)
+
+	_, _, err = m.Enqueue(Ticket{Mode: "duel"})
+	assert.Equal(t, ErrInvalidTicket, err)
+
+	_, _, err = m.Enqueue(Ticket{UserIDs: []string{"a", "b", "c"}, Mode: "duel"})
+	assert.Equal(t, ErrInvalidTicket, err)
+
+	_, _, err = m.Enqueue(Ticket{UserIDs: []string{"a", "a"}, Mode: "duel"})
+	assert.Equal(t, ErrInvalidTicket, err)
+
+	id, c, err := m.Enqueue(Ticket{UserIDs: []string{"a"}, Mode: "duel"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, m.QueueLen())
+
+	// a user is in one ticket at a time
+	_, _, err = m.Enqueue(Ticket{UserIDs: []string{"b", "a"}, Mode: "duel"})
+	assert.Equal(t, ErrInvalidTicket, err)
+
+	assert.Nil(t, m.Cancel(id))
+	assert.Equal(t, ErrTicketNotFound, m.Cancel(id))
+	_, ok := <-c
+	assert.False(t, ok)
+	assert.Equal(t, 0, m.QueueLen())
+
+	_, _, err = m.Enqueue(Ticket{UserIDs: []string{"a"}, Mode: "duel"})
+	assert.Nil(t, err)
+}
+
+func TestMatchmaker_CancelAllocating(t *testing.T) {
+	fleet, m := newTestMatchmaker(t, MatchRules{PlayersPerMatch: 2, SkillWindow: 100})
+	defer m.Stop()
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 10)
+
+	id1, c1, _ := m.Enqueue(Ticket{UserIDs: []string{"a"}, Mode: "duel"})
+	_, c2, _ := m.Enqueue(Ticket{UserIDs: []string{"b"}, Mode: "duel"})
+	pending := m.take(time.Now())
+	require.Len(t, pending, 1)
+	assert.Equal(t, ErrTicketMatched, m.Cancel(id1))
+	m.finish(pending, []bool{m.allocate(&pending[0]) == nil})
+
+	// the match is delivered though the cancel came too late
+	assert.Equal(t, <-c1, <-c2)
+	assert.Equal(t, ErrTicketNotFound, m.Cancel(id1))
+
+	// a canceled ticket is not queued again after a failed allocation
+	id3, c3, _ := m.Enqueue(Ticket{UserIDs: []string{"c"}, Mode: "duel"})
+	_, _, _ = m.Enqueue(Ticket{UserIDs: []string{"d"}, Mode: "duel"})
+	pending = m.take(time.Now())
+	require.Len(t, pending, 1)
+	assert.Equal(t, ErrTicketMatched, m.Cancel(id3))
+	m.finish(pending, []bool{false})
+	_, ok := <-c3
+	assert.False(t, ok)
+	assert.Equal(t, 1, m.QueueLen())
+	_, _, err := m.Enqueue(Ticket{UserIDs: []string{"c"}, Mode: "duel"})
+	assert.NoError(t, err)
+}
+
+func TestMatchmaker_SkillWindow(t *testing.T) {
+	fleet, m := newTestMatchmaker(t, MatchRules{PlayersPerMatch: 2, SkillWindow: 100, SkillWindowGrowth: 10, MaxSkillWindow: 200})
+	defer m.Stop()
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 10)
+
+	_, c1, _ := m.Enqueue(Ticket{UserIDs: []string{"a"}, Mode: "duel", SkillRating: 1000})
+	_, c2, _ := m.Enqueue(Ticket{UserIDs: []string{"b"}, Mode: "duel", SkillRating: 1150})
+	_, c3, _ := m.Enqueue(Ticket{UserIDs: []string{"c"}, Mode: "duel", SkillRating: 1500})
+
+	now := time.Now()
+	m.match(now)
+	assert.Equal(t, 3, m.QueueLen())
+
+	// the window is 150 after 5 seconds
+	m.match(now.Add(5 * time.Second))
+	assert.Equal(t, 1, m.QueueLen())
+
+	match1, match2 := <-c1, <-c2
+	assert.Equal(t, match1, match2)
+	assert.Equal(t, "duel", match1.Mode)
+	assert.ElementsMatch(t, []Seat{{"a", NoTeam}, {"b", NoTeam}}, match1.Seats)
+
+	addr, ok := fleet.LookupGameServerAddr(match1.RoomID)
+	assert.True(t, ok)
+	assert.Equal(t, match1.GameServer, addr)
+	rooms := fleet.RoomList()
+	assert.Len(t, rooms, 1)
+	assert.False(t, rooms[0].Visible)
+	assert.Equal(t, uint(2), rooms[0].MaxActors)
+	assert.ElementsMatch(t, []string{"a", "b"}, rooms[0].ReservedUserIDs)
+	assert.Equal(t, uint(2), rooms[0].ReservedSeats(time.Now()))
+
+	// the window does not exceed the max
+	m.match(now.Add(time.Hour))
+	assert.Equal(t, 1, m.QueueLen())
+	select {
+	case <-c3:
+		t.Fatal("unexpected match")
+	default:
+	}
+}
+
+func TestMatchmaker_Region(t *testing.T) {
+	fleet, m := newTestMatchmaker(t, MatchRules{PlayersPerMatch: 2, SkillWindow: 100, MaxLatencyMillis: 100})
+	defer m.Stop()
+	fleet.RegisterGameServerInRegion(GameServerAddr{"127.0.0.1", "10000"}, "eu", 10)
+	fleet.RegisterGameServerInRegion(GameServerAddr{"127.0.0.1", "20000"}, "us", 10)
+
+	_, c1, _ := m.Enqueue(Ticket{UserIDs: []string{"a"}, Mode: "duel", LatencyMillis: map[string]uint32{"eu": 30, "us": 80}})
+	_, _, _ = m.Enqueue(Ticket{UserIDs: []string{"b"}, Mode: "duel", Region: "asia"})
+	_, c3, _ := m.Enqueue(Ticket{UserIDs: []string{"c"}, Mode: "duel", LatencyMillis: map[string]uint32{"eu": 150, "us": 120}})
+	_, c4, _ := m.Enqueue(Ticket{UserIDs: []string{"d"}, Mode: "duel", LatencyMillis: map[string]uint32{"eu": 90, "us": 60}})
+
+	m.match(time.Now())
+	assert.Equal(t, 2, m.QueueLen())
+
+	match1, match4 := <-c1, <-c4
+	assert.Equal(t, match1, match4)
+	assert.Equal(t, "us", match1.Region)
+	assert.Equal(t, GameServerAddr{"127.0.0.1", "20000"}, match1.GameServer)
+
+	select {
+	case <-c3:
+		t.Fatal("unexpected match")
+	default:
+	}
+}
+
+func TestMatchmaker_Teams(t *testing.T) {
+	fleet, m := newTestMatchmaker(t, MatchRules{PlayersPerMatch: 4, TeamCount: 2, SkillWindow: 1000})
+	defer m.Stop()
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 10)
+	allocated := make(chan RoomAllocatedEvent, 1)
+	fleet.AddRoomAllocationListener(allocated)
+	defer fleet.RemoveRoomAllocationListener(allocated)
+
+	_, c1, _ := m.Enqueue(Ticket{UserIDs: []string{"a", "b"}, Mode: "duel", SkillRating: 1200})
+	_, _, _ = m.Enqueue(Ticket{UserIDs: []string{"d"}, Mode: "duel", SkillRating: 1100})
+
+	m.match(time.Now())
+	assert.Equal(t, 2, m.QueueLen())
+
+	_, _, _ = m.Enqueue(Ticket{UserIDs: []string{"e"}, Mode: "duel", SkillRating: 1300})
+	m.match(time.Now())
+	assert.Equal(t, 0, m.QueueLen())
+
+	match := <-c1
+	assert.ElementsMatch(t, []Seat{{"a", 1}, {"b", 1}, {"e", 2}, {"d", 2}}, match.Seats)
+
+	// the game server gets the same teams
+	ev := <-allocated
+	assert.Equal(t, match.RoomID, ev.Room.RoomID)
+	assert.Equal(t, uint(2), ev.TeamCount)
+	assert.Equal(t, map[string]uint32{"a": 1, "b": 1, "d": 2, "e": 2}, ev.UserTeams)
+}
+
+func TestMatchmaker_Parties(t *testing.T) {
+	fleet, m := newTestMatchmaker(t, MatchRules{PlayersPerMatch: 6, TeamCount: 2, SkillWindow: 1000})
+	defer m.Stop()
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 10)
+
+	_, c1, _ := m.Enqueue(Ticket{UserIDs: []string{"a", "b"}, Mode: "duel", SkillRating: 900})
+	_, _, _ = m.Enqueue(Ticket{UserIDs: []string{"c", "d"}, Mode: "duel", SkillRating: 1000})
+	_, _, _ = m.Enqueue(Ticket{UserIDs: []string{"e", "f"}, Mode: "duel", SkillRating: 1000})
+	_, _, _ = m.Enqueue(Ticket{UserIDs: []string{"g"}, Mode: "duel", SkillRating: 1000})
+	_, _, _ = m.Enqueue(Ticket{UserIDs: []string{"h"}, Mode: "duel", SkillRating: 1000})
+
+	// three parties of 2 do not fit in teams of 3, so the oldest ticket is
+	// matched with the next candidates
+	m.match(time.Now())
+	assert.Equal(t, 1, m.QueueLen())
+
+	var match Match
+	select {
+	case match = <-c1:
+	default:
+		t.Fatal("the oldest ticket is not matched")
+	}
+	teams := make(map[uint32]int)
+	users := make([]string, 0, len(match.Seats))
+	for _, seat := range match.Seats {
+		teams[seat.Team]++
+		users = append(users, seat.UserID)
+	}
+	assert.ElementsMatch(t, []string{"a", "b", "c", "d", "g", "h"}, users)
+	assert.Equal(t, map[uint32]int{1: 3, 2: 3}, teams)
+}
+
+func TestNewMatchmaker_InvalidRules(t *testing.T) {
+	for _, rules := range []MatchRules{
+		{},
+		{PlayersPerMatch: -2},
+		{PlayersPerMatch: 4, TeamCount: -1},
+		{PlayersPerMatch: 5, TeamCount: 2},
+	} {
+		_, err := NewMatchmaker(NewFleet(), MatchmakerOptions{Rules: map[string]MatchRules{"duel": rules}})
+		assert.True(t, errors.Is(err, ErrInvalidMatchRules), "%+v", rules)
+	}
+}
+
+func TestMatchmaker_NotEnoughGameServers(t *testing.T) {
+	fleet, m := newTestMatchmaker(t, MatchRules{PlayersPerMatch: 2, SkillWindow: 100})
+	defer m.Stop()
+
+	_, c1, _ := m.Enqueue(Ticket{UserIDs: []string{"a"}, Mode: "duel"})
+	_, c2, _ := m.Enqueue(Ticket{UserIDs: []string{"b"}, Mode: "duel"})
+
+	m.match(time.Now())
+	assert.Equal(t, 2, m.QueueLen())
+
+	fleet.RegisterGameServer(GameServerAddr{"127.0.0.1", "10000"}, 1)
+	m.match(time.Now())
+	assert.Equal(t, 0, m.QueueLen())
+	assert.Equal(t, <-c1, <-c2)
+
+	m.Stop()
+	_, _, err := m.Enqueue(Ticket{UserIDs: []string{"c"}, Mode: "duel"})
+	assert.Equal(t, ErrMatchmakerStopped, err)
+}
+
+func TestMatchmaker_Backfill(t *testing.T) {
+	fleet, m := newTestMatchmaker(t, MatchRules{PlayersPerMatch: 4, TeamCount: 2, SkillWindow: 100})
+	defer m.Stop()
+	fleet.RegisterGameServerInRegion(GameServerAddr{"127.0.0.1", "10000"}, "eu", 10)
+
+	roomID := quark.RoomID(rand.Uint64())
+	_, err := fleet.AllocateRoom(roomID, "", RoomOptions{MaxActors: 4, GameMode: "duel", Hidden: true})
+	require.NoError(t, err)
+	assert.Equal(t, ErrRoomStatusNotFound, fleet.RequestBackfill(Backfill{RoomID: quark.RoomID(rand.Uint64()), Slots: 1}))
+	require.NoError(t, fleet.RequestBackfill(Backfill{RoomID: roomID, Slots: 2, Team: 2, SkillRating: 1000}))
+
+	_, c1, _ := m.Enqueue(Ticket{UserIDs: []string{"a"}, Mode: "duel", SkillRating: 1500})
+	_, c2, _ := m.Enqueue(Ticket{UserIDs: []string{"b"}, Mode: "duel", SkillRating: 1050, Region: "us"})
+	_, c3, _ := m.Enqueue(Ticket{UserIDs: []string{"c"}, Mode: "duel", SkillRating: 950})
+
+	m.match(time.Now())
+	assert.Equal(t, 2, m.QueueLen())
+
+	match := <-c3
+	assert.Equal(t, roomID, match.RoomID)
+	assert.True(t, match.Backfill)
+	assert.Equal(t, []Seat{{"c", 2}}, match.Seats)
+
+	bs := fleet.Backfills()
+	require.Len(t, bs, 1)
+	assert.Equal(t, uint(1), bs[0].Slots)
+	rooms := fleet.RoomList()
+	require.Len(t, rooms, 1)
+	assert.Equal(t, uint(1), rooms[0].BackfillSlots)
+	assert.Equal(t, []string{"c"}, rooms[0].ReservedUserIDs)
+
+	require.NoError(t, fleet.RequestBackfill(Backfill{RoomID: roomID}))
+	assert.Empty(t, fleet.Backfills())
+	assert.Equal(t, uint(0), fleet.RoomList()[0].BackfillSlots)
+
+	m.match(time.Now())
+	assert.Equal(t, 2, m.QueueLen())
+	for _, c := range []<-chan Match{c1, c2} {
+		select {
+		case <-c:
+			t.Fatal("unexpected match")
+		default:
+		}
+	}
+}
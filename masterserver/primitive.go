@@ -1,16 +1,163 @@
 package masterserver
 
-import "quark"
+import (
+	"strings"
+	"time"
+
+	"quark"
+)
 
 type RoomStatus struct {
 	RoomID     quark.RoomID
 	RoomName   string
 	ActorCount uint
+	// MaxActors is 0 if the room has no limit
+	MaxActors uint
+	Open      bool
+	Visible   bool
+	Locked    bool
+	GameMode  string
+	Region    string
+	CreatedAt time.Time
+	// Properties are the room properties exposed to the lobby
+	Properties map[string]string
+	// Lobby is empty for the default lobby
+	Lobby string
+
+	// ReservedUserIDs are the users holding seats who have not joined yet
+	ReservedUserIDs []string
+	// ReservationExpiresAt is when the reserved seats are released
+	ReservationExpiresAt time.Time
+	// BackfillSlots is the number of players the running room asks for
+	BackfillSlots uint
+}
+
+// RoomFlags changes the flags of a room; a nil flag is left unchanged.
+type RoomFlags struct {
+	Open    *bool
+	Visible *bool
+	Locked  *bool
+}
+
+// ReservedSeats returns the number of seats held for users at the time
+func (r *RoomStatus) ReservedSeats(now time.Time) uint {
+	if !now.Before(r.ReservationExpiresAt) {
+		return 0
+	}
+	return uint(len(r.ReservedUserIDs))
+}
+
+// IsFull reports whether the room has no seats left, including the reserved seats
+func (r *RoomStatus) IsFull() bool {
+	return 0 < r.MaxActors && r.MaxActors <= r.ActorCount+r.ReservedSeats(time.Now())
+}
+
+type RoomOptions struct {
+	// MaxActors limits the number of actors in the room; 0 means no limit
+	MaxActors  uint
+	Properties map[string]string
+	// LobbyProperties are the keys of Properties exposed to the lobby
+	LobbyProperties []string
+	GameMode        string
+	// Region selects game servers in the region if not empty
+	Region string
+	Hidden bool
+	Locked bool
+	// Lobby lists the room in the named lobby; empty for the default lobby
+	Lobby string
+
+	// ReservedUserIDs hold seats under MaxActors for the users; other actors can not take them
+	ReservedUserIDs []string
+	// ReservationTTL releases the seats of users who have not joined in time;
+	// DefaultReservationTTL is used if 0
+	ReservationTTL time.Duration
+
+	// TeamCount and UserTeams are passed on to the game server, which puts
+	// the users into their teams, from 1, when they join
+	TeamCount uint
+	UserTeams map[string]uint32
+}
+
+const DefaultReservationTTL = 30 * time.Second
+
+func (o *RoomOptions) lobbyProperties() map[string]string {
+	props := make(map[string]string)
+	for _, k := range o.LobbyProperties {
+		if v, ok := o.Properties[k]; ok {
+			props[k] = v
+		}
+	}
+	return props
+}
+
+// RoomFilter selects rooms in Lobby; other zero values match any room
+type RoomFilter struct {
+	Lobby      string
+	NamePrefix string
+	Properties map[string]string
+	MinActors  uint
+	// MaxActors is 0 for no limit
+	MaxActors uint
+}
+
+func (f *RoomFilter) Match(r *RoomStatus) bool {
+	if !strings.HasPrefix(r.RoomName, f.NamePrefix) {
+		return false
+	}
+	if r.ActorCount < f.MinActors || (0 < f.MaxActors && f.MaxActors < r.ActorCount) {
+		return false
+	}
+	for k, v := range f.Properties {
+		if p, ok := r.Properties[k]; !ok || p != v {
+			return false
+		}
+	}
+	return true
 }
 
 type RoomAllocatedEvent struct {
 	GameServer GameServerAddr
 	Room       RoomStatus
+	TeamCount  uint
+	UserTeams  map[string]uint32
+}
+
+// SeatsReservedEvent reserves seats in an allocated room, e.g. for backfill
+type SeatsReservedEvent struct {
+	GameServer GameServerAddr
+	RoomID     quark.RoomID
+	UserIDs    []string
+	UserTeams  map[string]uint32
+	ExpiresAt  time.Time
+}
+
+// Backfill asks for players to fill the seats left in a running room
+type Backfill struct {
+	RoomID quark.RoomID
+	// Slots is the number of players wanted; 0 cancels the backfill
+	Slots uint
+	// Team is the team of the players, NoTeam for any team
+	Team uint32
+	// SkillRating is the rating the players are matched around
+	SkillRating float64
+
+	// Mode and Region are of the room
+	Mode   string
+	Region string
+}
+
+type RoomEventType int
+
+const (
+	RoomAdded RoomEventType = iota
+	RoomUpdated
+	RoomRemoved
+)
+
+// RoomEvent is a change of the room list
+type RoomEvent struct {
+	Type RoomEventType
+	Room RoomStatus
 }
 
 type GameServerID string
@@ -0,0 +1,521 @@
+package masterserver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidFilter = errors.New("invalid room filter")
+
+const (
+	// MaxFilterLength is the longest filter expression in bytes
+	MaxFilterLength = 4096
+	// MaxFilterDepth is how deep parentheses and ! can be nested
+	MaxFilterDepth = 64
+)
+
+// FilterError is returned for a filter expression which can not be parsed.
+type FilterError struct {
+	Pos    int
+	Reason string
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("invalid room filter at %d: %s", e.Pos, e.Reason)
+}
+
+func (e *FilterError) Is(target error) bool {
+	return target == ErrInvalidFilter
+}
+
+// RoomExpr is a parsed filter expression over rooms, e.g.
+//
+//	mode == "ctf" && actors < max && region == "ap-northeast"
+//
+// It supports ==, !=, <, <=, >, >=, &&, || and ! on strings, numbers and
+// booleans. The built-in fields are id, name, actors, max, open, visible,
+// locked, mode, region, created (unix seconds) and backfill (the players the
+// room asks for); max of a room without limit is infinite. Other names, or
+// names with the "props." prefix, are the lobby properties of the room;
+// property values which are numbers compare as numbers. A comparison with a
+// missing property is false.
+type RoomExpr struct {
+	src  string
+	root node
+}
+
+func ParseRoomExpr(src string) (*RoomExpr, error) {
+	if MaxFilterLength < len(src) {
+		return nil, &FilterError{Pos: MaxFilterLength, Reason: fmt.Sprintf("longer than %d bytes", MaxFilterLength)}
+	}
+	p := &parser{src: src}
+	p.next()
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %q", p.tok.text)
+	}
+	return &RoomExpr{src: src, root: root}, nil
+}
+
+func (e *RoomExpr) Match(r *RoomStatus) bool {
+	v := e.root.eval(r)
+	return v.kind == kindBool && v.b
+}
+
+func (e *RoomExpr) String() string {
+	return e.src
+}
+
+// SortKey orders rooms by a field of RoomExpr
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// RoomQuery selects the visible rooms in Lobby matching Filter, ordered by
+// Sort and then by ID
+type RoomQuery struct {
+	Lobby string
+	// Filter is nil to match all rooms
+	Filter *RoomExpr
+	Sort   []SortKey
+	// Limit is 0 for no limit
+	Limit int
+}
+
+func (q *RoomQuery) Match(r *RoomStatus) bool {
+	return r.Lobby == q.Lobby && r.Visible && (q.Filter == nil || q.Filter.Match(r))
+}
+
+// Apply returns the matching rooms in order
+func (q *RoomQuery) Apply(rooms []RoomStatus) []RoomStatus {
+	matched := make([]RoomStatus, 0, len(rooms))
+	for i := range rooms {
+		if q.Match(&rooms[i]) {
+			matched = append(matched, rooms[i])
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		for _, k := range q.Sort {
+			c := compareForSort(field(k.Field).eval(&matched[i]), field(k.Field).eval(&matched[j]))
+			if c != 0 {
+				return (c < 0) != k.Descending
+			}
+		}
+		return matched[i].RoomID < matched[j].RoomID
+	})
+	if 0 < q.Limit && q.Limit < len(matched) {
+		matched = matched[:q.Limit]
+	}
+	return matched
+}
+
+type kind int
+
+const (
+	kindNull kind = iota
+	kindString
+	kindNumber
+	kindBool
+)
+
+type value struct {
+	kind kind
+	s    string
+	n    float64
+	b    bool
+}
+
+// number returns the value as a number; strings are parsed
+func (v value) number() (float64, bool) {
+	switch v.kind {
+	case kindNumber:
+		return v.n, true
+	case kindString:
+		n, err := strconv.ParseFloat(v.s, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// compare returns the order of the values, and false if they are not comparable
+func compare(a, b value) (int, bool) {
+	switch {
+	case a.kind == kindNull || b.kind == kindNull:
+		return 0, false
+	case a.kind == kindBool && b.kind == kindBool:
+		if a.b == b.b {
+			return 0, true
+		} else if b.b {
+			return -1, true
+		}
+		return 1, true
+	case a.kind == kindString && b.kind == kindString:
+		return strings.Compare(a.s, b.s), true
+	}
+	x, ok1 := a.number()
+	y, ok2 := b.number()
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	switch {
+	case x < y:
+		return -1, true
+	case x > y:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// compareForSort orders values which are not comparable, e.g. missing
+// properties, last
+func compareForSort(a, b value) int {
+	if c, ok := compare(a, b); ok {
+		return c
+	}
+	switch {
+	case a.kind == kindNull && b.kind != kindNull:
+		return 1
+	case a.kind != kindNull && b.kind == kindNull:
+		return -1
+	default:
+		return int(a.kind) - int(b.kind)
+	}
+}
+
+type node interface {
+	eval(r *RoomStatus) value
+}
+
+type literal value
+
+func (l literal) eval(*RoomStatus) value { return value(l) }
+
+type field string
+
+func (f field) eval(r *RoomStatus) value {
+	switch f {
+	case "id":
+		return value{kind: kindNumber, n: float64(r.RoomID)}
+	case "name":
+		return value{kind: kindString, s: r.RoomName}
+	case "actors":
+		return value{kind: kindNumber, n: float64(r.ActorCount)}
+	case "max":
+		if r.MaxActors == 0 {
+			return value{kind: kindNumber, n: math.Inf(1)}
+		}
+		return value{kind: kindNumber, n: float64(r.MaxActors)}
+	case "open":
+		return value{kind: kindBool, b: r.Open}
+	case "visible":
+		return value{kind: kindBool, b: r.Visible}
+	case "locked":
+		return value{kind: kindBool, b: r.Locked}
+	case "mode":
+		return value{kind: kindString, s: r.GameMode}
+	case "region":
+		return value{kind: kindString, s: r.Region}
+	case "created":
+		return value{kind: kindNumber, n: float64(r.CreatedAt.Unix())}
+	case "backfill":
+		return value{kind: kindNumber, n: float64(r.BackfillSlots)}
+	}
+	if p, ok := r.Properties[strings.TrimPrefix(string(f), "props.")]; ok {
+		if n, err := strconv.ParseFloat(p, 64); err == nil {
+			return value{kind: kindNumber, n: n}
+		}
+		return value{kind: kindString, s: p}
+	}
+	return value{}
+}
+
+type not struct{ x node }
+
+func (n not) eval(r *RoomStatus) value {
+	v := n.x.eval(r)
+	if v.kind != kindBool {
+		return value{}
+	}
+	return value{kind: kindBool, b: !v.b}
+}
+
+type logical struct {
+	op   string
+	x, y node
+}
+
+func (l logical) eval(r *RoomStatus) value {
+	x := l.x.eval(r)
+	xb := x.kind == kindBool && x.b
+	if l.op == "&&" && !xb {
+		return value{kind: kindBool}
+	} else if l.op == "||" && xb {
+		return value{kind: kindBool, b: true}
+	}
+	y := l.y.eval(r)
+	return value{kind: kindBool, b: y.kind == kindBool && y.b}
+}
+
+type comparison struct {
+	op   string
+	x, y node
+}
+
+func (c comparison) eval(r *RoomStatus) value {
+	o, ok := compare(c.x.eval(r), c.y.eval(r))
+	if !ok {
+		return value{kind: kindBool}
+	}
+	var b bool
+	switch c.op {
+	case "==":
+		b = o == 0
+	case "!=":
+		b = o != 0
+	case "<":
+		b = o < 0
+	case "<=":
+		b = o <= 0
+	case ">":
+		b = o > 0
+	case ">=":
+		b = o >= 0
+	}
+	return value{kind: kindBool, b: b}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	src   string
+	pos   int
+	tok   token
+	err   error
+	depth int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &FilterError{Pos: p.tok.pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+// enter is called before parsing a nested expression; leave after it
+func (p *parser) enter() error {
+	p.depth++
+	if MaxFilterDepth < p.depth {
+		return p.errorf("nested deeper than %d", MaxFilterDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+// next reads the next token into p.tok; a lexical error is kept in p.err
+func (p *parser) next() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if len(p.src) <= p.pos {
+		p.tok = token{kind: tokEOF, pos: start}
+		return
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case c == '"':
+		p.pos++
+		for p.pos < len(p.src) && p.src[p.pos] != '"' {
+			if p.src[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if len(p.src) <= p.pos {
+			p.tok = token{kind: tokEOF, pos: start}
+			p.err = &FilterError{Pos: start, Reason: "unterminated string"}
+			return
+		}
+		p.pos++
+		p.tok = token{kind: tokString, text: p.src[start:p.pos], pos: start}
+	case c == '-' || c == '.' || ('0' <= c && c <= '9'):
+		p.pos++
+		for p.pos < len(p.src) && (p.src[p.pos] == '.' || ('0' <= p.src[p.pos] && p.src[p.pos] <= '9')) {
+			p.pos++
+		}
+		p.tok = token{kind: tokNumber, text: p.src[start:p.pos], pos: start}
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || p.src[p.pos] == '.' || unicode.IsLetter(rune(p.src[p.pos])) || unicode.IsDigit(rune(p.src[p.pos]))) {
+			p.pos++
+		}
+		p.tok = token{kind: tokIdent, text: p.src[start:p.pos], pos: start}
+	default:
+		for _, op := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")"} {
+			if strings.HasPrefix(p.src[p.pos:], op) {
+				p.pos += len(op)
+				p.tok = token{kind: tokOp, text: op, pos: start}
+				return
+			}
+		}
+		p.tok = token{kind: tokEOF, pos: start}
+		p.err = &FilterError{Pos: start, Reason: fmt.Sprintf("unexpected %q", c)}
+	}
+}
+
+func (p *parser) isOp(ops ...string) bool {
+	if p.tok.kind != tokOp {
+		return false
+	}
+	for _, op := range ops {
+		if p.tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseOr() (node, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		p.next()
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = logical{op: "||", x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		p.next()
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = logical{op: "&&", x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOp("!") {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return not{x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOp("==", "!=", "<", "<=", ">", ">=") {
+		op := p.tok.text
+		p.next()
+		y, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return comparison{op: op, x: x, y: y}, nil
+	}
+	return x, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	tok := p.tok
+	switch tok.kind {
+	case tokString:
+		s, err := strconv.Unquote(tok.text)
+		if err != nil {
+			return nil, p.errorf("invalid string %s", tok.text)
+		}
+		p.next()
+		return literal{kind: kindString, s: s}, nil
+	case tokNumber:
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %s", tok.text)
+		}
+		p.next()
+		return literal{kind: kindNumber, n: n}, nil
+	case tokIdent:
+		p.next()
+		switch tok.text {
+		case "true":
+			return literal{kind: kindBool, b: true}, nil
+		case "false":
+			return literal{kind: kindBool, b: false}, nil
+		}
+		return field(tok.text), nil
+	case tokOp:
+		if tok.text == "(" {
+			if err := p.enter(); err != nil {
+				return nil, err
+			}
+			defer p.leave()
+			p.next()
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if !p.isOp(")") {
+				return nil, p.errorf("missing )")
+			}
+			p.next()
+			return x, nil
+		}
+	case tokEOF:
+		return nil, p.errorf("unexpected end")
+	}
+	return nil, p.errorf("unexpected %q", tok.text)
+}
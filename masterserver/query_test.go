@@ -0,0 +1,112 @@
+package masterserver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomExpr(t *testing.T) {
+	room := RoomStatus{
+		RoomID:     42,
+		RoomName:   "alpha",
+		ActorCount: 3,
+		MaxActors:  8,
+		Open:       true,
+		Visible:    true,
+		GameMode:   "ctf",
+		Region:     "ap-northeast",
+		CreatedAt:  time.Unix(1000, 0),
+		Properties: map[string]string{"map": "forest", "level": "12", "mode": "custom"},
+
+		BackfillSlots: 2,
+	}
+
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{`mode == "ctf" && actors < max && region == "ap-northeast"`, true},
+		{`mode == "dm" || actors >= 3`, true},
+		{`!(open && !locked)`, false},
+		{`open`, true},
+		{`name != "alpha"`, false},
+		{`name < "beta"`, true},
+		{`level >= 10`, true},
+		{`level > "2"`, true},
+		{`name > "2"`, true},
+		{`map == "forest" && props.mode == "custom"`, true},
+		{`missing == "x"`, false},
+		{`missing != "x"`, false},
+		{`id == 42 && created == 1000`, true},
+		{`actors == "three"`, false},
+		{`locked == false`, true},
+		{`backfill > 0 && backfill < max`, true},
+		{`actors`, false},
+	}
+	for _, tt := range tests {
+		expr, err := ParseRoomExpr(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.match, expr.Match(&room), tt.expr)
+	}
+
+	unlimited := RoomStatus{ActorCount: 100}
+	expr, err := ParseRoomExpr("actors < max")
+	require.NoError(t, err)
+	assert.True(t, expr.Match(&unlimited))
+}
+
+func TestRoomExpr_Errors(t *testing.T) {
+	for _, src := range []string{
+		`mode ==`,
+		`(open`,
+		`"unterminated`,
+		`actors < 1 $`,
+		`open open`,
+		`== 1`,
+		``,
+		strings.Repeat("(", MaxFilterDepth+1) + "open" + strings.Repeat(")", MaxFilterDepth+1),
+		strings.Repeat("!", MaxFilterDepth+1) + "open",
+		strings.Repeat("(", 3*1024*1024),
+		`mode == "` + strings.Repeat("x", MaxFilterLength) + `"`,
+	} {
+		_, err := ParseRoomExpr(src)
+		assert.True(t, errors.Is(err, ErrInvalidFilter), src)
+	}
+
+	_, err := ParseRoomExpr(strings.Repeat("(", MaxFilterDepth) + "open" + strings.Repeat(")", MaxFilterDepth))
+	assert.NoError(t, err)
+}
+
+func TestRoomQuery_Apply(t *testing.T) {
+	rooms := []RoomStatus{
+		{RoomID: 1, ActorCount: 2, Visible: true, Properties: map[string]string{"level": "5"}},
+		{RoomID: 2, ActorCount: 4, Visible: true},
+		{RoomID: 3, ActorCount: 4, Visible: true, Properties: map[string]string{"level": "10"}},
+		{RoomID: 4, ActorCount: 9, Visible: false},
+	}
+
+	ids := func(rs []RoomStatus) []uint64 {
+		ids := make([]uint64, len(rs))
+		for i, r := range rs {
+			ids[i] = r.RoomID.Uint64()
+		}
+		return ids
+	}
+
+	q := RoomQuery{Sort: []SortKey{{Field: "actors", Descending: true}}}
+	assert.Equal(t, []uint64{2, 3, 1}, ids(q.Apply(rooms)))
+
+	// missing properties are sorted last
+	q = RoomQuery{Sort: []SortKey{{Field: "level"}}}
+	assert.Equal(t, []uint64{1, 3, 2}, ids(q.Apply(rooms)))
+
+	expr, err := ParseRoomExpr("actors > 2")
+	require.NoError(t, err)
+	q = RoomQuery{Filter: expr, Limit: 1}
+	assert.Equal(t, []uint64{2}, ids(q.Apply(rooms)))
+}
@@ -0,0 +1,77 @@
+package masterserver
+
+import "sort"
+
+type LobbyStats struct {
+	Lobby string
+	Rooms uint
+	// ActorsInRooms is the number of actors in the rooms of the lobby
+	ActorsInRooms uint
+	// ActorsInLobby is the number of clients subscribed to the room list of the lobby
+	ActorsInLobby uint
+}
+
+type GameServerStats struct {
+	ID     GameServerID
+	Addr   GameServerAddr
+	Region string
+	Rooms  uint
+	Actors uint
+	// FreeCapacity is the number of rooms the game server can still allocate
+	FreeCapacity uint
+}
+
+type FleetStats struct {
+	// Lobbies are sorted by name
+	Lobbies     []LobbyStats
+	GameServers []GameServerStats
+
+	Rooms            uint
+	ActorsInRooms    uint
+	ActorsInLobbies  uint
+	FreeRoomCapacity uint
+}
+
+// Stats aggregates the current state of the fleet
+func (f *Fleet) Stats() FleetStats {
+	f.mux.RLock()
+	defer f.mux.RUnlock()
+
+	lobbies := make(map[string]*LobbyStats)
+	lobby := func(name string) *LobbyStats {
+		s, ok := lobbies[name]
+		if !ok {
+			s = &LobbyStats{Lobby: name}
+			lobbies[name] = s
+		}
+		return s
+	}
+
+	var stats FleetStats
+	for name, rooms := range f.lobbies {
+		s := lobby(name)
+		for roomID := range rooms {
+			s.Rooms++
+			s.ActorsInRooms += f.rs[roomID].ActorCount
+		}
+		stats.Rooms += s.Rooms
+		stats.ActorsInRooms += s.ActorsInRooms
+	}
+	for _, l := range f.roomListeners {
+		lobby(l.lobby).ActorsInLobby++
+		stats.ActorsInLobbies++
+	}
+	for _, s := range lobbies {
+		stats.Lobbies = append(stats.Lobbies, *s)
+	}
+	sort.Slice(stats.Lobbies, func(i, j int) bool {
+		return stats.Lobbies[i].Lobby < stats.Lobbies[j].Lobby
+	})
+
+	for _, g := range f.g {
+		s := g.Stats()
+		stats.GameServers = append(stats.GameServers, s)
+		stats.FreeRoomCapacity += s.FreeCapacity
+	}
+	return stats
+}
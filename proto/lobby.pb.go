@@ -119,25 +119,902 @@ type InLobbyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// the max number of rooms in a snapshot page; 0 uses the server default
+	PageSize uint32 `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// lists only the rooms matching the filter; see ListRoomsRequest
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// the order of the snapshot
+	SortBy []*SortKey `protobuf:"bytes,3,rep,name=sortBy,proto3" json:"sortBy,omitempty"`
+	// empty for the default lobby
+	Lobby string `protobuf:"bytes,4,opt,name=lobby,proto3" json:"lobby,omitempty"`
+}
+
+func (x *InLobbyRequest) Reset() {
+	*x = InLobbyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InLobbyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InLobbyRequest) ProtoMessage() {}
+
+func (x *InLobbyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InLobbyRequest.ProtoReflect.Descriptor instead.
+func (*InLobbyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InLobbyRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *InLobbyRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *InLobbyRequest) GetSortBy() []*SortKey {
+	if x != nil {
+		return x.SortBy
+	}
+	return nil
+}
+
+func (x *InLobbyRequest) GetLobby() string {
+	if x != nil {
+		return x.Lobby
+	}
+	return ""
+}
+
+// the first messages are the pages of a snapshot of the room list, followed by
+// the changes. A new snapshot replaces the room list of the client.
+type InLobbyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Message:
+	//	*InLobbyMessage_OnRoomListSnapshot
+	//	*InLobbyMessage_OnRoomAdded
+	//	*InLobbyMessage_OnRoomUpdated
+	//	*InLobbyMessage_OnRoomRemoved
+	//	*InLobbyMessage_OnStats
+	Message isInLobbyMessage_Message `protobuf_oneof:"message"`
+}
+
+func (x *InLobbyMessage) Reset() {
+	*x = InLobbyMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InLobbyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InLobbyMessage) ProtoMessage() {}
+
+func (x *InLobbyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InLobbyMessage.ProtoReflect.Descriptor instead.
+func (*InLobbyMessage) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{3}
+}
+
+func (m *InLobbyMessage) GetMessage() isInLobbyMessage_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *InLobbyMessage) GetOnRoomListSnapshot() *InLobbyMessage_RoomListSnapshot {
+	if x, ok := x.GetMessage().(*InLobbyMessage_OnRoomListSnapshot); ok {
+		return x.OnRoomListSnapshot
+	}
+	return nil
+}
+
+func (x *InLobbyMessage) GetOnRoomAdded() *InLobbyMessage_RoomAdded {
+	if x, ok := x.GetMessage().(*InLobbyMessage_OnRoomAdded); ok {
+		return x.OnRoomAdded
+	}
+	return nil
+}
+
+func (x *InLobbyMessage) GetOnRoomUpdated() *InLobbyMessage_RoomUpdated {
+	if x, ok := x.GetMessage().(*InLobbyMessage_OnRoomUpdated); ok {
+		return x.OnRoomUpdated
+	}
+	return nil
+}
+
+func (x *InLobbyMessage) GetOnRoomRemoved() *InLobbyMessage_RoomRemoved {
+	if x, ok := x.GetMessage().(*InLobbyMessage_OnRoomRemoved); ok {
+		return x.OnRoomRemoved
+	}
+	return nil
+}
+
+func (x *InLobbyMessage) GetOnStats() *FleetStats {
+	if x, ok := x.GetMessage().(*InLobbyMessage_OnStats); ok {
+		return x.OnStats
+	}
+	return nil
+}
+
+type isInLobbyMessage_Message interface {
+	isInLobbyMessage_Message()
+}
+
+type InLobbyMessage_OnRoomListSnapshot struct {
+	OnRoomListSnapshot *InLobbyMessage_RoomListSnapshot `protobuf:"bytes,2,opt,name=onRoomListSnapshot,proto3,oneof"`
+}
+
+type InLobbyMessage_OnRoomAdded struct {
+	OnRoomAdded *InLobbyMessage_RoomAdded `protobuf:"bytes,3,opt,name=onRoomAdded,proto3,oneof"`
+}
+
+type InLobbyMessage_OnRoomUpdated struct {
+	OnRoomUpdated *InLobbyMessage_RoomUpdated `protobuf:"bytes,4,opt,name=onRoomUpdated,proto3,oneof"`
+}
+
+type InLobbyMessage_OnRoomRemoved struct {
+	OnRoomRemoved *InLobbyMessage_RoomRemoved `protobuf:"bytes,5,opt,name=onRoomRemoved,proto3,oneof"`
+}
+
+type InLobbyMessage_OnStats struct {
+	// sent periodically
+	OnStats *FleetStats `protobuf:"bytes,6,opt,name=onStats,proto3,oneof"`
+}
+
+func (*InLobbyMessage_OnRoomListSnapshot) isInLobbyMessage_Message() {}
+
+func (*InLobbyMessage_OnRoomAdded) isInLobbyMessage_Message() {}
+
+func (*InLobbyMessage_OnRoomUpdated) isInLobbyMessage_Message() {}
+
+func (*InLobbyMessage_OnRoomRemoved) isInLobbyMessage_Message() {}
+
+func (*InLobbyMessage_OnStats) isInLobbyMessage_Message() {}
+
+// all filters are optional
+type JoinRandomRoomRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomNamePrefix string            `protobuf:"bytes,1,opt,name=roomNamePrefix,proto3" json:"roomNamePrefix,omitempty"`
+	Properties     map[string]string `protobuf:"bytes,2,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MinActors      uint32            `protobuf:"varint,3,opt,name=minActors,proto3" json:"minActors,omitempty"`
+	// 0 means no limit
+	MaxActors uint32 `protobuf:"varint,4,opt,name=maxActors,proto3" json:"maxActors,omitempty"`
+	// empty for the default lobby
+	Lobby string `protobuf:"bytes,5,opt,name=lobby,proto3" json:"lobby,omitempty"`
+}
+
+func (x *JoinRandomRoomRequest) Reset() {
+	*x = JoinRandomRoomRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinRandomRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRandomRoomRequest) ProtoMessage() {}
+
+func (x *JoinRandomRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRandomRoomRequest.ProtoReflect.Descriptor instead.
+func (*JoinRandomRoomRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *JoinRandomRoomRequest) GetRoomNamePrefix() string {
+	if x != nil {
+		return x.RoomNamePrefix
+	}
+	return ""
+}
+
+func (x *JoinRandomRoomRequest) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *JoinRandomRoomRequest) GetMinActors() uint32 {
+	if x != nil {
+		return x.MinActors
+	}
+	return 0
+}
+
+func (x *JoinRandomRoomRequest) GetMaxActors() uint32 {
+	if x != nil {
+		return x.MaxActors
+	}
+	return 0
+}
+
+func (x *JoinRandomRoomRequest) GetLobby() string {
+	if x != nil {
+		return x.Lobby
+	}
+	return ""
+}
+
+type JoinRandomRoomResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server *primitive.GameServer `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	RoomID uint64                `protobuf:"varint,2,opt,name=roomID,proto3" json:"roomID,omitempty"`
+}
+
+func (x *JoinRandomRoomResponse) Reset() {
+	*x = JoinRandomRoomResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinRandomRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRandomRoomResponse) ProtoMessage() {}
+
+func (x *JoinRandomRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRandomRoomResponse.ProtoReflect.Descriptor instead.
+func (*JoinRandomRoomResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *JoinRandomRoomResponse) GetServer() *primitive.GameServer {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *JoinRandomRoomResponse) GetRoomID() uint64 {
+	if x != nil {
+		return x.RoomID
+	}
+	return 0
+}
+
+type JoinOrCreateRoomRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomName string `protobuf:"bytes,1,opt,name=roomName,proto3" json:"roomName,omitempty"`
+	// used only if the room is created
+	RoomOptions *RoomOptions `protobuf:"bytes,2,opt,name=roomOptions,proto3" json:"roomOptions,omitempty"`
+}
+
+func (x *JoinOrCreateRoomRequest) Reset() {
+	*x = JoinOrCreateRoomRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinOrCreateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinOrCreateRoomRequest) ProtoMessage() {}
+
+func (x *JoinOrCreateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinOrCreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*JoinOrCreateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *JoinOrCreateRoomRequest) GetRoomName() string {
+	if x != nil {
+		return x.RoomName
+	}
+	return ""
+}
+
+func (x *JoinOrCreateRoomRequest) GetRoomOptions() *RoomOptions {
+	if x != nil {
+		return x.RoomOptions
+	}
+	return nil
+}
+
+type JoinOrCreateRoomResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server  *primitive.GameServer `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	RoomID  uint64                `protobuf:"varint,2,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Created bool                  `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+}
+
+func (x *JoinOrCreateRoomResponse) Reset() {
+	*x = JoinOrCreateRoomResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinOrCreateRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinOrCreateRoomResponse) ProtoMessage() {}
+
+func (x *JoinOrCreateRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinOrCreateRoomResponse.ProtoReflect.Descriptor instead.
+func (*JoinOrCreateRoomResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JoinOrCreateRoomResponse) GetServer() *primitive.GameServer {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *JoinOrCreateRoomResponse) GetRoomID() uint64 {
+	if x != nil {
+		return x.RoomID
+	}
+	return 0
+}
+
+func (x *JoinOrCreateRoomResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+type ListRoomsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// a filter expression over room fields, e.g.
+	//   mode == "ctf" && actors < max && region == "ap-northeast"
+	// The fields are id, name, actors, max, open, visible, locked, mode, region,
+	// created (unix seconds) and the lobby properties of the room, also as
+	// props.<key>. The operators are ==, !=, <, <=, >, >=, &&, || and !.
+	// Empty matches all rooms.
+	Filter string `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// rooms are ordered by the keys, then by roomID
+	SortBy []*SortKey `protobuf:"bytes,2,rep,name=sortBy,proto3" json:"sortBy,omitempty"`
+	// 0 uses the server default
+	Limit uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// empty for the default lobby
+	Lobby string `protobuf:"bytes,4,opt,name=lobby,proto3" json:"lobby,omitempty"`
+}
+
+func (x *ListRoomsRequest) Reset() {
+	*x = ListRoomsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRoomsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsRequest) ProtoMessage() {}
+
+func (x *ListRoomsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoomsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListRoomsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListRoomsRequest) GetSortBy() []*SortKey {
+	if x != nil {
+		return x.SortBy
+	}
+	return nil
+}
+
+func (x *ListRoomsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRoomsRequest) GetLobby() string {
+	if x != nil {
+		return x.Lobby
+	}
+	return ""
+}
+
+type ListRoomsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomList []*primitive.Room `protobuf:"bytes,1,rep,name=roomList,proto3" json:"roomList,omitempty"`
+}
+
+func (x *ListRoomsResponse) Reset() {
+	*x = ListRoomsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRoomsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsResponse) ProtoMessage() {}
+
+func (x *ListRoomsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoomsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListRoomsResponse) GetRoomList() []*primitive.Room {
+	if x != nil {
+		return x.RoomList
+	}
+	return nil
+}
+
+type SortKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field      string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Descending bool   `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+}
+
+func (x *SortKey) Reset() {
+	*x = SortKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SortKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortKey) ProtoMessage() {}
+
+func (x *SortKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortKey.ProtoReflect.Descriptor instead.
+func (*SortKey) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SortKey) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SortKey) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{11}
+}
+
+type FleetStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lobbies         []*FleetStats_LobbyStats      `protobuf:"bytes,1,rep,name=lobbies,proto3" json:"lobbies,omitempty"`
+	GameServers     []*FleetStats_GameServerStats `protobuf:"bytes,2,rep,name=gameServers,proto3" json:"gameServers,omitempty"`
+	Rooms           uint32                        `protobuf:"varint,3,opt,name=rooms,proto3" json:"rooms,omitempty"`
+	ActorsInRooms   uint32                        `protobuf:"varint,4,opt,name=actorsInRooms,proto3" json:"actorsInRooms,omitempty"`
+	ActorsInLobbies uint32                        `protobuf:"varint,5,opt,name=actorsInLobbies,proto3" json:"actorsInLobbies,omitempty"`
+	// the number of rooms the game servers can still allocate
+	FreeRoomCapacity uint32 `protobuf:"varint,6,opt,name=freeRoomCapacity,proto3" json:"freeRoomCapacity,omitempty"`
+}
+
+func (x *FleetStats) Reset() {
+	*x = FleetStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FleetStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FleetStats) ProtoMessage() {}
+
+func (x *FleetStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FleetStats.ProtoReflect.Descriptor instead.
+func (*FleetStats) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FleetStats) GetLobbies() []*FleetStats_LobbyStats {
+	if x != nil {
+		return x.Lobbies
+	}
+	return nil
+}
+
+func (x *FleetStats) GetGameServers() []*FleetStats_GameServerStats {
+	if x != nil {
+		return x.GameServers
+	}
+	return nil
+}
+
+func (x *FleetStats) GetRooms() uint32 {
+	if x != nil {
+		return x.Rooms
+	}
+	return 0
+}
+
+func (x *FleetStats) GetActorsInRooms() uint32 {
+	if x != nil {
+		return x.ActorsInRooms
+	}
+	return 0
+}
+
+func (x *FleetStats) GetActorsInLobbies() uint32 {
+	if x != nil {
+		return x.ActorsInLobbies
+	}
+	return 0
+}
+
+func (x *FleetStats) GetFreeRoomCapacity() uint32 {
+	if x != nil {
+		return x.FreeRoomCapacity
+	}
+	return 0
+}
+
+type InLobbyMessage_RoomListSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomList []*primitive.Room `protobuf:"bytes,1,rep,name=roomList,proto3" json:"roomList,omitempty"`
+	// pages are numbered from 0
+	Page      uint32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageCount uint32 `protobuf:"varint,3,opt,name=pageCount,proto3" json:"pageCount,omitempty"`
+}
+
+func (x *InLobbyMessage_RoomListSnapshot) Reset() {
+	*x = InLobbyMessage_RoomListSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InLobbyMessage_RoomListSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InLobbyMessage_RoomListSnapshot) ProtoMessage() {}
+
+func (x *InLobbyMessage_RoomListSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InLobbyMessage_RoomListSnapshot.ProtoReflect.Descriptor instead.
+func (*InLobbyMessage_RoomListSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *InLobbyMessage_RoomListSnapshot) GetRoomList() []*primitive.Room {
+	if x != nil {
+		return x.RoomList
+	}
+	return nil
+}
+
+func (x *InLobbyMessage_RoomListSnapshot) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *InLobbyMessage_RoomListSnapshot) GetPageCount() uint32 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+type InLobbyMessage_RoomAdded struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Room *primitive.Room `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+}
+
+func (x *InLobbyMessage_RoomAdded) Reset() {
+	*x = InLobbyMessage_RoomAdded{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InLobbyMessage_RoomAdded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InLobbyMessage_RoomAdded) ProtoMessage() {}
+
+func (x *InLobbyMessage_RoomAdded) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *InLobbyRequest) Reset() {
-	*x = InLobbyRequest{}
+// Deprecated: Use InLobbyMessage_RoomAdded.ProtoReflect.Descriptor instead.
+func (*InLobbyMessage_RoomAdded) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *InLobbyMessage_RoomAdded) GetRoom() *primitive.Room {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+type InLobbyMessage_RoomUpdated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Room *primitive.Room `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+}
+
+func (x *InLobbyMessage_RoomUpdated) Reset() {
+	*x = InLobbyMessage_RoomUpdated{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_lobby_proto_msgTypes[2]
+		mi := &file_proto_lobby_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InLobbyRequest) String() string {
+func (x *InLobbyMessage_RoomUpdated) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InLobbyRequest) ProtoMessage() {}
+func (*InLobbyMessage_RoomUpdated) ProtoMessage() {}
 
-func (x *InLobbyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_lobby_proto_msgTypes[2]
+func (x *InLobbyMessage_RoomUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -148,38 +1025,43 @@ func (x *InLobbyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InLobbyRequest.ProtoReflect.Descriptor instead.
-func (*InLobbyRequest) Descriptor() ([]byte, []int) {
-	return file_proto_lobby_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use InLobbyMessage_RoomUpdated.ProtoReflect.Descriptor instead.
+func (*InLobbyMessage_RoomUpdated) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{3, 2}
 }
 
-type InLobbyMessage struct {
+func (x *InLobbyMessage_RoomUpdated) GetRoom() *primitive.Room {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+type InLobbyMessage_RoomRemoved struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Message:
-	//	*InLobbyMessage_OnUpdatedRoomList
-	Message isInLobbyMessage_Message `protobuf_oneof:"message"`
+	RoomID uint64 `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
 }
 
-func (x *InLobbyMessage) Reset() {
-	*x = InLobbyMessage{}
+func (x *InLobbyMessage_RoomRemoved) Reset() {
+	*x = InLobbyMessage_RoomRemoved{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_lobby_proto_msgTypes[3]
+		mi := &file_proto_lobby_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InLobbyMessage) String() string {
+func (x *InLobbyMessage_RoomRemoved) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InLobbyMessage) ProtoMessage() {}
+func (*InLobbyMessage_RoomRemoved) ProtoMessage() {}
 
-func (x *InLobbyMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_lobby_proto_msgTypes[3]
+func (x *InLobbyMessage_RoomRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -190,60 +1072,120 @@ func (x *InLobbyMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InLobbyMessage.ProtoReflect.Descriptor instead.
-func (*InLobbyMessage) Descriptor() ([]byte, []int) {
-	return file_proto_lobby_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use InLobbyMessage_RoomRemoved.ProtoReflect.Descriptor instead.
+func (*InLobbyMessage_RoomRemoved) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{3, 3}
 }
 
-func (m *InLobbyMessage) GetMessage() isInLobbyMessage_Message {
-	if m != nil {
-		return m.Message
+func (x *InLobbyMessage_RoomRemoved) GetRoomID() uint64 {
+	if x != nil {
+		return x.RoomID
 	}
-	return nil
+	return 0
+}
+
+type FleetStats_LobbyStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// empty for the default lobby
+	Lobby string `protobuf:"bytes,1,opt,name=lobby,proto3" json:"lobby,omitempty"`
+	Rooms uint32 `protobuf:"varint,2,opt,name=rooms,proto3" json:"rooms,omitempty"`
+	// actors in the rooms of the lobby
+	ActorsInRooms uint32 `protobuf:"varint,3,opt,name=actorsInRooms,proto3" json:"actorsInRooms,omitempty"`
+	// clients in the lobby, subscribed to its room list
+	ActorsInLobby uint32 `protobuf:"varint,4,opt,name=actorsInLobby,proto3" json:"actorsInLobby,omitempty"`
 }
 
-func (x *InLobbyMessage) GetOnUpdatedRoomList() *InLobbyMessage_RoomListUpdatedEvent {
-	if x, ok := x.GetMessage().(*InLobbyMessage_OnUpdatedRoomList); ok {
-		return x.OnUpdatedRoomList
+func (x *FleetStats_LobbyStats) Reset() {
+	*x = FleetStats_LobbyStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_lobby_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-type isInLobbyMessage_Message interface {
-	isInLobbyMessage_Message()
+func (x *FleetStats_LobbyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FleetStats_LobbyStats) ProtoMessage() {}
+
+func (x *FleetStats_LobbyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FleetStats_LobbyStats.ProtoReflect.Descriptor instead.
+func (*FleetStats_LobbyStats) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{12, 0}
+}
+
+func (x *FleetStats_LobbyStats) GetLobby() string {
+	if x != nil {
+		return x.Lobby
+	}
+	return ""
 }
 
-type InLobbyMessage_OnUpdatedRoomList struct {
-	OnUpdatedRoomList *InLobbyMessage_RoomListUpdatedEvent `protobuf:"bytes,1,opt,name=onUpdatedRoomList,proto3,oneof"`
+func (x *FleetStats_LobbyStats) GetRooms() uint32 {
+	if x != nil {
+		return x.Rooms
+	}
+	return 0
+}
+
+func (x *FleetStats_LobbyStats) GetActorsInRooms() uint32 {
+	if x != nil {
+		return x.ActorsInRooms
+	}
+	return 0
 }
 
-func (*InLobbyMessage_OnUpdatedRoomList) isInLobbyMessage_Message() {}
+func (x *FleetStats_LobbyStats) GetActorsInLobby() uint32 {
+	if x != nil {
+		return x.ActorsInLobby
+	}
+	return 0
+}
 
-type InLobbyMessage_RoomListUpdatedEvent struct {
+type FleetStats_GameServerStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RoomList []*primitive.Room `protobuf:"bytes,1,rep,name=roomList,proto3" json:"roomList,omitempty"`
+	Server       *primitive.GameServer `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Rooms        uint32                `protobuf:"varint,2,opt,name=rooms,proto3" json:"rooms,omitempty"`
+	Actors       uint32                `protobuf:"varint,3,opt,name=actors,proto3" json:"actors,omitempty"`
+	FreeCapacity uint32                `protobuf:"varint,4,opt,name=freeCapacity,proto3" json:"freeCapacity,omitempty"`
 }
 
-func (x *InLobbyMessage_RoomListUpdatedEvent) Reset() {
-	*x = InLobbyMessage_RoomListUpdatedEvent{}
+func (x *FleetStats_GameServerStats) Reset() {
+	*x = FleetStats_GameServerStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_lobby_proto_msgTypes[4]
+		mi := &file_proto_lobby_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InLobbyMessage_RoomListUpdatedEvent) String() string {
+func (x *FleetStats_GameServerStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InLobbyMessage_RoomListUpdatedEvent) ProtoMessage() {}
+func (*FleetStats_GameServerStats) ProtoMessage() {}
 
-func (x *InLobbyMessage_RoomListUpdatedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_lobby_proto_msgTypes[4]
+func (x *FleetStats_GameServerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,18 +1196,39 @@ func (x *InLobbyMessage_RoomListUpdatedEvent) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InLobbyMessage_RoomListUpdatedEvent.ProtoReflect.Descriptor instead.
-func (*InLobbyMessage_RoomListUpdatedEvent) Descriptor() ([]byte, []int) {
-	return file_proto_lobby_proto_rawDescGZIP(), []int{3, 0}
+// Deprecated: Use FleetStats_GameServerStats.ProtoReflect.Descriptor instead.
+func (*FleetStats_GameServerStats) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{12, 1}
 }
 
-func (x *InLobbyMessage_RoomListUpdatedEvent) GetRoomList() []*primitive.Room {
+func (x *FleetStats_GameServerStats) GetServer() *primitive.GameServer {
 	if x != nil {
-		return x.RoomList
+		return x.Server
 	}
 	return nil
 }
 
+func (x *FleetStats_GameServerStats) GetRooms() uint32 {
+	if x != nil {
+		return x.Rooms
+	}
+	return 0
+}
+
+func (x *FleetStats_GameServerStats) GetActors() uint32 {
+	if x != nil {
+		return x.Actors
+	}
+	return 0
+}
+
+func (x *FleetStats_GameServerStats) GetFreeCapacity() uint32 {
+	if x != nil {
+		return x.FreeCapacity
+	}
+	return 0
+}
+
 var File_proto_lobby_proto protoreflect.FileDescriptor
 
 var file_proto_lobby_proto_rawDesc = []byte{
@@ -283,34 +1246,181 @@ var file_proto_lobby_proto_rawDesc = []byte{
 	0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x71, 0x75, 0x61,
 	0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x47, 0x61, 0x6d,
 	0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22,
-	0x10, 0x0a, 0x0e, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0xc2, 0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x5a, 0x0a, 0x11, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2a, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x11, 0x6f,
-	0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74,
-	0x1a, 0x49, 0x0a, 0x14, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x08, 0x72, 0x6f, 0x6f, 0x6d,
-	0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x61,
-	0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x52, 0x6f, 0x6f,
-	0x6d, 0x52, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xc2, 0x01, 0x0a, 0x05, 0x4c, 0x6f, 0x62, 0x62, 0x79,
-	0x12, 0x41, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x18,
-	0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f,
-	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x12, 0x15,
-	0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x49, 0x6e,
-	0x4c, 0x6f, 0x62, 0x62, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x30, 0x01, 0x12, 0x3b,
-	0x0a, 0x08, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x16, 0x2e, 0x71, 0x75, 0x61,
-	0x72, 0x6b, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52,
-	0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0d, 0x5a, 0x0b, 0x71,
-	0x75, 0x61, 0x72, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x82, 0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53,
+	0x6f, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x6f, 0x62, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x6f, 0x62, 0x62, 0x79, 0x22, 0x91, 0x05, 0x0a, 0x0e, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x58, 0x0a, 0x12, 0x6f, 0x6e, 0x52, 0x6f, 0x6f,
+	0x6d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x49, 0x6e, 0x4c, 0x6f,
+	0x62, 0x62, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x12, 0x6f,
+	0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x12, 0x43, 0x0a, 0x0b, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x64, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x49,
+	0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x41, 0x64, 0x64, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x6f, 0x6e, 0x52, 0x6f, 0x6f,
+	0x6d, 0x41, 0x64, 0x64, 0x65, 0x64, 0x12, 0x49, 0x0a, 0x0d, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x6d,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x0d, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x49, 0x0a, 0x0d, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
+	0x2e, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0d, 0x6f,
+	0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x07,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x46, 0x6c, 0x65, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x48, 0x00, 0x52, 0x07, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x1a, 0x77, 0x0a, 0x10, 0x52,
+	0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
+	0x31, 0x0a, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74,
+	0x69, 0x76, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x36, 0x0a, 0x09, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x64, 0x65,
+	0x64, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x1a, 0x38, 0x0a, 0x0b,
+	0x52, 0x6f, 0x6f, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x04, 0x72,
+	0x6f, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x61, 0x72,
+	0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
+	0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x1a, 0x25, 0x0a, 0x0b, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x42, 0x09, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x9e, 0x02, 0x0a, 0x15, 0x4a, 0x6f, 0x69,
+	0x6e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x6f, 0x6f, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x6f, 0x6f, 0x6d,
+	0x4e, 0x61, 0x6d, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x4c, 0x0a, 0x0a, 0x70, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c,
+	0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x61, 0x6e, 0x64, 0x6f,
+	0x6d, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x41,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d, 0x69, 0x6e,
+	0x41, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x62, 0x62, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x62, 0x62, 0x79, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x65, 0x0a, 0x16, 0x4a, 0x6f, 0x69,
+	0x6e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d,
+	0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d,
+	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44,
+	0x22, 0x6b, 0x0a, 0x17, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x6f, 0x6f, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x6f, 0x6f, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x0b, 0x72, 0x6f, 0x6f, 0x6d, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x0b, 0x72, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x81, 0x01,
+	0x0a, 0x18, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f,
+	0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x71, 0x75, 0x61,
+	0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x47, 0x61, 0x6d,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x22, 0x7e, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a,
+	0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x73,
+	0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x6f, 0x62, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x62, 0x62,
+	0x79, 0x22, 0x46, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
+	0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x52,
+	0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x07, 0x53, 0x6f, 0x72,
+	0x74, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65,
+	0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xbd, 0x04,
+	0x0a, 0x0a, 0x46, 0x6c, 0x65, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x07,
+	0x6c, 0x6f, 0x62, 0x62, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x46, 0x6c, 0x65, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x2e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x6c, 0x6f, 0x62,
+	0x62, 0x69, 0x65, 0x73, 0x12, 0x43, 0x0a, 0x0b, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x71, 0x75, 0x61, 0x72,
+	0x6b, 0x2e, 0x46, 0x6c, 0x65, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x47, 0x61, 0x6d,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0b, 0x67, 0x61,
+	0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6f,
+	0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x49, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x49, 0x6e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x49,
+	0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x69, 0x65, 0x73, 0x12,
+	0x2a, 0x0a, 0x10, 0x66, 0x72, 0x65, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x63,
+	0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x66, 0x72, 0x65, 0x65, 0x52,
+	0x6f, 0x6f, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x1a, 0x84, 0x01, 0x0a, 0x0a,
+	0x4c, 0x6f, 0x62, 0x62, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f,
+	0x62, 0x62, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x62, 0x62, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x49, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x49, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x49, 0x6e, 0x4c, 0x6f, 0x62,
+	0x62, 0x79, 0x1a, 0x98, 0x01, 0x0a, 0x0f, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x70,
+	0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x6f, 0x6d,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x72, 0x65,
+	0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0c, 0x66, 0x72, 0x65, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x32, 0xdd, 0x03,
+	0x0a, 0x05, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x12, 0x41, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x18, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f,
+	0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x49, 0x6e,
+	0x4c, 0x6f, 0x62, 0x62, 0x79, 0x12, 0x15, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x49, 0x6e,
+	0x4c, 0x6f, 0x62, 0x62, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x49, 0x6e, 0x4c, 0x6f, 0x62, 0x62, 0x79, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x08, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f,
+	0x6d, 0x12, 0x16, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x71, 0x75, 0x61, 0x72,
+	0x6b, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d,
+	0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x1c, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4a, 0x6f, 0x69,
+	0x6e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52,
+	0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x53, 0x0a, 0x10, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x1e, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4a, 0x6f,
+	0x69, 0x6e, 0x4f, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4a, 0x6f,
+	0x69, 0x6e, 0x4f, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f,
+	0x6f, 0x6d, 0x73, 0x12, 0x17, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x16, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x71, 0x75, 0x61,
+	0x72, 0x6b, 0x2e, 0x46, 0x6c, 0x65, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x0d, 0x5a,
+	0x0b, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -325,33 +1435,73 @@ func file_proto_lobby_proto_rawDescGZIP() []byte {
 	return file_proto_lobby_proto_rawDescData
 }
 
-var file_proto_lobby_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_lobby_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_lobby_proto_goTypes = []interface{}{
-	(*JoinRoomRequest)(nil),                     // 0: quark.JoinRoomRequest
-	(*JoinRoomResponse)(nil),                    // 1: quark.JoinRoomResponse
-	(*InLobbyRequest)(nil),                      // 2: quark.InLobbyRequest
-	(*InLobbyMessage)(nil),                      // 3: quark.InLobbyMessage
-	(*InLobbyMessage_RoomListUpdatedEvent)(nil), // 4: quark.InLobbyMessage.RoomListUpdatedEvent
-	(*primitive.GameServer)(nil),                // 5: quark.primitive.GameServer
-	(*primitive.Room)(nil),                      // 6: quark.primitive.Room
-	(*CreateRoomRequest)(nil),                   // 7: quark.CreateRoomRequest
-	(*CreateRoomResponse)(nil),                  // 8: quark.CreateRoomResponse
+	(*JoinRoomRequest)(nil),                 // 0: quark.JoinRoomRequest
+	(*JoinRoomResponse)(nil),                // 1: quark.JoinRoomResponse
+	(*InLobbyRequest)(nil),                  // 2: quark.InLobbyRequest
+	(*InLobbyMessage)(nil),                  // 3: quark.InLobbyMessage
+	(*JoinRandomRoomRequest)(nil),           // 4: quark.JoinRandomRoomRequest
+	(*JoinRandomRoomResponse)(nil),          // 5: quark.JoinRandomRoomResponse
+	(*JoinOrCreateRoomRequest)(nil),         // 6: quark.JoinOrCreateRoomRequest
+	(*JoinOrCreateRoomResponse)(nil),        // 7: quark.JoinOrCreateRoomResponse
+	(*ListRoomsRequest)(nil),                // 8: quark.ListRoomsRequest
+	(*ListRoomsResponse)(nil),               // 9: quark.ListRoomsResponse
+	(*SortKey)(nil),                         // 10: quark.SortKey
+	(*GetStatsRequest)(nil),                 // 11: quark.GetStatsRequest
+	(*FleetStats)(nil),                      // 12: quark.FleetStats
+	(*InLobbyMessage_RoomListSnapshot)(nil), // 13: quark.InLobbyMessage.RoomListSnapshot
+	(*InLobbyMessage_RoomAdded)(nil),        // 14: quark.InLobbyMessage.RoomAdded
+	(*InLobbyMessage_RoomUpdated)(nil),      // 15: quark.InLobbyMessage.RoomUpdated
+	(*InLobbyMessage_RoomRemoved)(nil),      // 16: quark.InLobbyMessage.RoomRemoved
+	nil,                                     // 17: quark.JoinRandomRoomRequest.PropertiesEntry
+	(*FleetStats_LobbyStats)(nil),           // 18: quark.FleetStats.LobbyStats
+	(*FleetStats_GameServerStats)(nil),      // 19: quark.FleetStats.GameServerStats
+	(*primitive.GameServer)(nil),            // 20: quark.primitive.GameServer
+	(*RoomOptions)(nil),                     // 21: quark.RoomOptions
+	(*primitive.Room)(nil),                  // 22: quark.primitive.Room
+	(*CreateRoomRequest)(nil),               // 23: quark.CreateRoomRequest
+	(*CreateRoomResponse)(nil),              // 24: quark.CreateRoomResponse
 }
 var file_proto_lobby_proto_depIdxs = []int32{
-	5, // 0: quark.JoinRoomResponse.server:type_name -> quark.primitive.GameServer
-	4, // 1: quark.InLobbyMessage.onUpdatedRoomList:type_name -> quark.InLobbyMessage.RoomListUpdatedEvent
-	6, // 2: quark.InLobbyMessage.RoomListUpdatedEvent.roomList:type_name -> quark.primitive.Room
-	7, // 3: quark.Lobby.CreateRoom:input_type -> quark.CreateRoomRequest
-	2, // 4: quark.Lobby.InLobby:input_type -> quark.InLobbyRequest
-	0, // 5: quark.Lobby.JoinRoom:input_type -> quark.JoinRoomRequest
-	8, // 6: quark.Lobby.CreateRoom:output_type -> quark.CreateRoomResponse
-	3, // 7: quark.Lobby.InLobby:output_type -> quark.InLobbyMessage
-	1, // 8: quark.Lobby.JoinRoom:output_type -> quark.JoinRoomResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	20, // 0: quark.JoinRoomResponse.server:type_name -> quark.primitive.GameServer
+	10, // 1: quark.InLobbyRequest.sortBy:type_name -> quark.SortKey
+	13, // 2: quark.InLobbyMessage.onRoomListSnapshot:type_name -> quark.InLobbyMessage.RoomListSnapshot
+	14, // 3: quark.InLobbyMessage.onRoomAdded:type_name -> quark.InLobbyMessage.RoomAdded
+	15, // 4: quark.InLobbyMessage.onRoomUpdated:type_name -> quark.InLobbyMessage.RoomUpdated
+	16, // 5: quark.InLobbyMessage.onRoomRemoved:type_name -> quark.InLobbyMessage.RoomRemoved
+	12, // 6: quark.InLobbyMessage.onStats:type_name -> quark.FleetStats
+	17, // 7: quark.JoinRandomRoomRequest.properties:type_name -> quark.JoinRandomRoomRequest.PropertiesEntry
+	20, // 8: quark.JoinRandomRoomResponse.server:type_name -> quark.primitive.GameServer
+	21, // 9: quark.JoinOrCreateRoomRequest.roomOptions:type_name -> quark.RoomOptions
+	20, // 10: quark.JoinOrCreateRoomResponse.server:type_name -> quark.primitive.GameServer
+	10, // 11: quark.ListRoomsRequest.sortBy:type_name -> quark.SortKey
+	22, // 12: quark.ListRoomsResponse.roomList:type_name -> quark.primitive.Room
+	18, // 13: quark.FleetStats.lobbies:type_name -> quark.FleetStats.LobbyStats
+	19, // 14: quark.FleetStats.gameServers:type_name -> quark.FleetStats.GameServerStats
+	22, // 15: quark.InLobbyMessage.RoomListSnapshot.roomList:type_name -> quark.primitive.Room
+	22, // 16: quark.InLobbyMessage.RoomAdded.room:type_name -> quark.primitive.Room
+	22, // 17: quark.InLobbyMessage.RoomUpdated.room:type_name -> quark.primitive.Room
+	20, // 18: quark.FleetStats.GameServerStats.server:type_name -> quark.primitive.GameServer
+	23, // 19: quark.Lobby.CreateRoom:input_type -> quark.CreateRoomRequest
+	2,  // 20: quark.Lobby.InLobby:input_type -> quark.InLobbyRequest
+	0,  // 21: quark.Lobby.JoinRoom:input_type -> quark.JoinRoomRequest
+	4,  // 22: quark.Lobby.JoinRandomRoom:input_type -> quark.JoinRandomRoomRequest
+	6,  // 23: quark.Lobby.JoinOrCreateRoom:input_type -> quark.JoinOrCreateRoomRequest
+	8,  // 24: quark.Lobby.ListRooms:input_type -> quark.ListRoomsRequest
+	11, // 25: quark.Lobby.GetStats:input_type -> quark.GetStatsRequest
+	24, // 26: quark.Lobby.CreateRoom:output_type -> quark.CreateRoomResponse
+	3,  // 27: quark.Lobby.InLobby:output_type -> quark.InLobbyMessage
+	1,  // 28: quark.Lobby.JoinRoom:output_type -> quark.JoinRoomResponse
+	5,  // 29: quark.Lobby.JoinRandomRoom:output_type -> quark.JoinRandomRoomResponse
+	7,  // 30: quark.Lobby.JoinOrCreateRoom:output_type -> quark.JoinOrCreateRoomResponse
+	9,  // 31: quark.Lobby.ListRooms:output_type -> quark.ListRoomsResponse
+	12, // 32: quark.Lobby.GetStats:output_type -> quark.FleetStats
+	26, // [26:33] is the sub-list for method output_type
+	19, // [19:26] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_proto_lobby_proto_init() }
@@ -410,7 +1560,175 @@ func file_proto_lobby_proto_init() {
 			}
 		}
 		file_proto_lobby_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InLobbyMessage_RoomListUpdatedEvent); i {
+			switch v := v.(*JoinRandomRoomRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinRandomRoomResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinOrCreateRoomRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinOrCreateRoomResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRoomsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRoomsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SortKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FleetStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InLobbyMessage_RoomListSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InLobbyMessage_RoomAdded); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InLobbyMessage_RoomUpdated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InLobbyMessage_RoomRemoved); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FleetStats_LobbyStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_lobby_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FleetStats_GameServerStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -423,7 +1741,11 @@ func file_proto_lobby_proto_init() {
 		}
 	}
 	file_proto_lobby_proto_msgTypes[3].OneofWrappers = []interface{}{
-		(*InLobbyMessage_OnUpdatedRoomList)(nil),
+		(*InLobbyMessage_OnRoomListSnapshot)(nil),
+		(*InLobbyMessage_OnRoomAdded)(nil),
+		(*InLobbyMessage_OnRoomUpdated)(nil),
+		(*InLobbyMessage_OnRoomRemoved)(nil),
+		(*InLobbyMessage_OnStats)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -431,7 +1753,7 @@ func file_proto_lobby_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_lobby_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
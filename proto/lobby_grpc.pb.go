@@ -21,6 +21,13 @@ type LobbyClient interface {
 	CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*CreateRoomResponse, error)
 	InLobby(ctx context.Context, in *InLobbyRequest, opts ...grpc.CallOption) (Lobby_InLobbyClient, error)
 	JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (*JoinRoomResponse, error)
+	// joins an open, visible and non-full room chosen at random
+	JoinRandomRoom(ctx context.Context, in *JoinRandomRoomRequest, opts ...grpc.CallOption) (*JoinRandomRoomResponse, error)
+	// joins the room of the name, creating it if no room in the fleet has the name
+	JoinOrCreateRoom(ctx context.Context, in *JoinOrCreateRoomRequest, opts ...grpc.CallOption) (*JoinOrCreateRoomResponse, error)
+	// returns the visible rooms matching the filter
+	ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*FleetStats, error)
 }
 
 type lobbyClient struct {
@@ -81,6 +88,42 @@ func (c *lobbyClient) JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ..
 	return out, nil
 }
 
+func (c *lobbyClient) JoinRandomRoom(ctx context.Context, in *JoinRandomRoomRequest, opts ...grpc.CallOption) (*JoinRandomRoomResponse, error) {
+	out := new(JoinRandomRoomResponse)
+	err := c.cc.Invoke(ctx, "/quark.Lobby/JoinRandomRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyClient) JoinOrCreateRoom(ctx context.Context, in *JoinOrCreateRoomRequest, opts ...grpc.CallOption) (*JoinOrCreateRoomResponse, error) {
+	out := new(JoinOrCreateRoomResponse)
+	err := c.cc.Invoke(ctx, "/quark.Lobby/JoinOrCreateRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyClient) ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error) {
+	out := new(ListRoomsResponse)
+	err := c.cc.Invoke(ctx, "/quark.Lobby/ListRooms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*FleetStats, error) {
+	out := new(FleetStats)
+	err := c.cc.Invoke(ctx, "/quark.Lobby/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LobbyServer is the server API for Lobby service.
 // All implementations must embed UnimplementedLobbyServer
 // for forward compatibility
@@ -88,6 +131,13 @@ type LobbyServer interface {
 	CreateRoom(context.Context, *CreateRoomRequest) (*CreateRoomResponse, error)
 	InLobby(*InLobbyRequest, Lobby_InLobbyServer) error
 	JoinRoom(context.Context, *JoinRoomRequest) (*JoinRoomResponse, error)
+	// joins an open, visible and non-full room chosen at random
+	JoinRandomRoom(context.Context, *JoinRandomRoomRequest) (*JoinRandomRoomResponse, error)
+	// joins the room of the name, creating it if no room in the fleet has the name
+	JoinOrCreateRoom(context.Context, *JoinOrCreateRoomRequest) (*JoinOrCreateRoomResponse, error)
+	// returns the visible rooms matching the filter
+	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	GetStats(context.Context, *GetStatsRequest) (*FleetStats, error)
 	mustEmbedUnimplementedLobbyServer()
 }
 
@@ -104,6 +154,18 @@ func (UnimplementedLobbyServer) InLobby(*InLobbyRequest, Lobby_InLobbyServer) er
 func (UnimplementedLobbyServer) JoinRoom(context.Context, *JoinRoomRequest) (*JoinRoomResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method JoinRoom not implemented")
 }
+func (UnimplementedLobbyServer) JoinRandomRoom(context.Context, *JoinRandomRoomRequest) (*JoinRandomRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinRandomRoom not implemented")
+}
+func (UnimplementedLobbyServer) JoinOrCreateRoom(context.Context, *JoinOrCreateRoomRequest) (*JoinOrCreateRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinOrCreateRoom not implemented")
+}
+func (UnimplementedLobbyServer) ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRooms not implemented")
+}
+func (UnimplementedLobbyServer) GetStats(context.Context, *GetStatsRequest) (*FleetStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
 func (UnimplementedLobbyServer) mustEmbedUnimplementedLobbyServer() {}
 
 // UnsafeLobbyServer may be embedded to opt out of forward compatibility for this service.
@@ -174,6 +236,78 @@ func _Lobby_JoinRoom_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lobby_JoinRandomRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRandomRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServer).JoinRandomRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quark.Lobby/JoinRandomRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServer).JoinRandomRoom(ctx, req.(*JoinRandomRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lobby_JoinOrCreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinOrCreateRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServer).JoinOrCreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quark.Lobby/JoinOrCreateRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServer).JoinOrCreateRoom(ctx, req.(*JoinOrCreateRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lobby_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServer).ListRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quark.Lobby/ListRooms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServer).ListRooms(ctx, req.(*ListRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lobby_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/quark.Lobby/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Lobby_ServiceDesc is the grpc.ServiceDesc for Lobby service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -189,6 +323,22 @@ var Lobby_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "JoinRoom",
 			Handler:    _Lobby_JoinRoom_Handler,
 		},
+		{
+			MethodName: "JoinRandomRoom",
+			Handler:    _Lobby_JoinRandomRoom_Handler,
+		},
+		{
+			MethodName: "JoinOrCreateRoom",
+			Handler:    _Lobby_JoinOrCreateRoom_Handler,
+		},
+		{
+			MethodName: "ListRooms",
+			Handler:    _Lobby_ListRooms_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Lobby_GetStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
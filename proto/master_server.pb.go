@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	primitive "quark/proto/primitive"
 	reflect "reflect"
 	sync "sync"
@@ -77,6 +78,7 @@ type MasterServerMessage struct {
 	// Types that are assignable to Message:
 	//	*MasterServerMessage_Registered
 	//	*MasterServerMessage_Allocation
+	//	*MasterServerMessage_Reservation
 	Message isMasterServerMessage_Message `protobuf_oneof:"message"`
 }
 
@@ -133,6 +135,13 @@ func (x *MasterServerMessage) GetAllocation() *MasterServerMessage_RoomAllocatio
 	return nil
 }
 
+func (x *MasterServerMessage) GetReservation() *MasterServerMessage_SeatReservation {
+	if x, ok := x.GetMessage().(*MasterServerMessage_Reservation); ok {
+		return x.Reservation
+	}
+	return nil
+}
+
 type isMasterServerMessage_Message interface {
 	isMasterServerMessage_Message()
 }
@@ -145,16 +154,27 @@ type MasterServerMessage_Allocation struct {
 	Allocation *MasterServerMessage_RoomAllocation `protobuf:"bytes,2,opt,name=allocation,proto3,oneof"`
 }
 
+type MasterServerMessage_Reservation struct {
+	Reservation *MasterServerMessage_SeatReservation `protobuf:"bytes,3,opt,name=reservation,proto3,oneof"`
+}
+
 func (*MasterServerMessage_Registered) isMasterServerMessage_Message() {}
 
 func (*MasterServerMessage_Allocation) isMasterServerMessage_Message() {}
 
+func (*MasterServerMessage_Reservation) isMasterServerMessage_Message() {}
+
 type GameServerStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	UpdateRoomState []*GameServerStatus_RoomState `protobuf:"bytes,1,rep,name=updateRoomState,proto3" json:"updateRoomState,omitempty"`
+	// rooms closed since the last status
+	ClosedRoomIDs []uint64 `protobuf:"varint,2,rep,packed,name=closedRoomIDs,proto3" json:"closedRoomIDs,omitempty"`
+	// asks for players to fill the seats left in running rooms
+	BackfillRequests []*GameServerStatus_BackfillRequest `protobuf:"bytes,3,rep,name=backfillRequests,proto3" json:"backfillRequests,omitempty"`
+	SetRoomFlags     []*GameServerStatus_SetRoomFlags    `protobuf:"bytes,4,rep,name=setRoomFlags,proto3" json:"setRoomFlags,omitempty"`
 }
 
 func (x *GameServerStatus) Reset() {
@@ -196,6 +216,27 @@ func (x *GameServerStatus) GetUpdateRoomState() []*GameServerStatus_RoomState {
 	return nil
 }
 
+func (x *GameServerStatus) GetClosedRoomIDs() []uint64 {
+	if x != nil {
+		return x.ClosedRoomIDs
+	}
+	return nil
+}
+
+func (x *GameServerStatus) GetBackfillRequests() []*GameServerStatus_BackfillRequest {
+	if x != nil {
+		return x.BackfillRequests
+	}
+	return nil
+}
+
+func (x *GameServerStatus) GetSetRoomFlags() []*GameServerStatus_SetRoomFlags {
+	if x != nil {
+		return x.SetRoomFlags
+	}
+	return nil
+}
+
 type MasterServerMessage_GameServerRegistered struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -249,6 +290,13 @@ type MasterServerMessage_RoomAllocation struct {
 	unknownFields protoimpl.UnknownFields
 
 	Room *primitive.Room `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	// the users holding seats in the room
+	ReservedUserIDs []string `protobuf:"bytes,2,rep,name=reservedUserIDs,proto3" json:"reservedUserIDs,omitempty"`
+	// the reserved seats of users who have not joined are released after the time
+	ReservationTTLMillis uint32 `protobuf:"varint,3,opt,name=reservationTTLMillis,proto3" json:"reservationTTLMillis,omitempty"`
+	// the teams of the users, from 1, e.g. as balanced by the matchmaker
+	TeamCount uint32            `protobuf:"varint,4,opt,name=teamCount,proto3" json:"teamCount,omitempty"`
+	UserTeams map[string]uint32 `protobuf:"bytes,5,rep,name=userTeams,proto3" json:"userTeams,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
 func (x *MasterServerMessage_RoomAllocation) Reset() {
@@ -290,6 +338,108 @@ func (x *MasterServerMessage_RoomAllocation) GetRoom() *primitive.Room {
 	return nil
 }
 
+func (x *MasterServerMessage_RoomAllocation) GetReservedUserIDs() []string {
+	if x != nil {
+		return x.ReservedUserIDs
+	}
+	return nil
+}
+
+func (x *MasterServerMessage_RoomAllocation) GetReservationTTLMillis() uint32 {
+	if x != nil {
+		return x.ReservationTTLMillis
+	}
+	return 0
+}
+
+func (x *MasterServerMessage_RoomAllocation) GetTeamCount() uint32 {
+	if x != nil {
+		return x.TeamCount
+	}
+	return 0
+}
+
+func (x *MasterServerMessage_RoomAllocation) GetUserTeams() map[string]uint32 {
+	if x != nil {
+		return x.UserTeams
+	}
+	return nil
+}
+
+// reserves seats in an allocated room, e.g. for the players of a backfill
+type MasterServerMessage_SeatReservation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomID    uint64   `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	UserIDs   []string `protobuf:"bytes,2,rep,name=userIDs,proto3" json:"userIDs,omitempty"`
+	TtlMillis uint32   `protobuf:"varint,3,opt,name=ttlMillis,proto3" json:"ttlMillis,omitempty"`
+	// the teams of the users, from 1; users without a team are not in it
+	UserTeams map[string]uint32 `protobuf:"bytes,4,rep,name=userTeams,proto3" json:"userTeams,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *MasterServerMessage_SeatReservation) Reset() {
+	*x = MasterServerMessage_SeatReservation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_master_server_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MasterServerMessage_SeatReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MasterServerMessage_SeatReservation) ProtoMessage() {}
+
+func (x *MasterServerMessage_SeatReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_master_server_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MasterServerMessage_SeatReservation.ProtoReflect.Descriptor instead.
+func (*MasterServerMessage_SeatReservation) Descriptor() ([]byte, []int) {
+	return file_proto_master_server_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *MasterServerMessage_SeatReservation) GetRoomID() uint64 {
+	if x != nil {
+		return x.RoomID
+	}
+	return 0
+}
+
+func (x *MasterServerMessage_SeatReservation) GetUserIDs() []string {
+	if x != nil {
+		return x.UserIDs
+	}
+	return nil
+}
+
+func (x *MasterServerMessage_SeatReservation) GetTtlMillis() uint32 {
+	if x != nil {
+		return x.TtlMillis
+	}
+	return 0
+}
+
+func (x *MasterServerMessage_SeatReservation) GetUserTeams() map[string]uint32 {
+	if x != nil {
+		return x.UserTeams
+	}
+	return nil
+}
+
+// the flags of room are ignored, see SetRoomFlags
 type GameServerStatus_RoomState struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -297,12 +447,14 @@ type GameServerStatus_RoomState struct {
 
 	Room       *primitive.Room `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
 	ActorCount uint64          `protobuf:"varint,2,opt,name=actorCount,proto3" json:"actorCount,omitempty"`
+	// the users whose reserved seats are still held
+	ReservedUserIDs []string `protobuf:"bytes,3,rep,name=reservedUserIDs,proto3" json:"reservedUserIDs,omitempty"`
 }
 
 func (x *GameServerStatus_RoomState) Reset() {
 	*x = GameServerStatus_RoomState{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_master_server_proto_msgTypes[5]
+		mi := &file_proto_master_server_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -315,7 +467,7 @@ func (x *GameServerStatus_RoomState) String() string {
 func (*GameServerStatus_RoomState) ProtoMessage() {}
 
 func (x *GameServerStatus_RoomState) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_master_server_proto_msgTypes[5]
+	mi := &file_proto_master_server_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -345,6 +497,159 @@ func (x *GameServerStatus_RoomState) GetActorCount() uint64 {
 	return 0
 }
 
+func (x *GameServerStatus_RoomState) GetReservedUserIDs() []string {
+	if x != nil {
+		return x.ReservedUserIDs
+	}
+	return nil
+}
+
+// changes the flags of a room; an unset flag is left unchanged
+type GameServerStatus_SetRoomFlags struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomID  uint64                `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Open    *wrapperspb.BoolValue `protobuf:"bytes,2,opt,name=open,proto3" json:"open,omitempty"`
+	Visible *wrapperspb.BoolValue `protobuf:"bytes,3,opt,name=visible,proto3" json:"visible,omitempty"`
+	Locked  *wrapperspb.BoolValue `protobuf:"bytes,4,opt,name=locked,proto3" json:"locked,omitempty"`
+}
+
+func (x *GameServerStatus_SetRoomFlags) Reset() {
+	*x = GameServerStatus_SetRoomFlags{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_master_server_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameServerStatus_SetRoomFlags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameServerStatus_SetRoomFlags) ProtoMessage() {}
+
+func (x *GameServerStatus_SetRoomFlags) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_master_server_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameServerStatus_SetRoomFlags.ProtoReflect.Descriptor instead.
+func (*GameServerStatus_SetRoomFlags) Descriptor() ([]byte, []int) {
+	return file_proto_master_server_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *GameServerStatus_SetRoomFlags) GetRoomID() uint64 {
+	if x != nil {
+		return x.RoomID
+	}
+	return 0
+}
+
+func (x *GameServerStatus_SetRoomFlags) GetOpen() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Open
+	}
+	return nil
+}
+
+func (x *GameServerStatus_SetRoomFlags) GetVisible() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Visible
+	}
+	return nil
+}
+
+func (x *GameServerStatus_SetRoomFlags) GetLocked() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Locked
+	}
+	return nil
+}
+
+type GameServerStatus_BackfillRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomID uint64 `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	// the number of players wanted; 0 cancels the backfill of the room
+	Slots uint32 `protobuf:"varint,2,opt,name=slots,proto3" json:"slots,omitempty"`
+	// the team of the players; 0 for any team
+	Team uint32 `protobuf:"varint,3,opt,name=team,proto3" json:"team,omitempty"`
+	// the rating the players are matched around
+	SkillRating float64 `protobuf:"fixed64,4,opt,name=skillRating,proto3" json:"skillRating,omitempty"`
+}
+
+func (x *GameServerStatus_BackfillRequest) Reset() {
+	*x = GameServerStatus_BackfillRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_master_server_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameServerStatus_BackfillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameServerStatus_BackfillRequest) ProtoMessage() {}
+
+func (x *GameServerStatus_BackfillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_master_server_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameServerStatus_BackfillRequest.ProtoReflect.Descriptor instead.
+func (*GameServerStatus_BackfillRequest) Descriptor() ([]byte, []int) {
+	return file_proto_master_server_proto_rawDescGZIP(), []int{2, 2}
+}
+
+func (x *GameServerStatus_BackfillRequest) GetRoomID() uint64 {
+	if x != nil {
+		return x.RoomID
+	}
+	return 0
+}
+
+func (x *GameServerStatus_BackfillRequest) GetSlots() uint32 {
+	if x != nil {
+		return x.Slots
+	}
+	return 0
+}
+
+func (x *GameServerStatus_BackfillRequest) GetTeam() uint32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
+func (x *GameServerStatus_BackfillRequest) GetSkillRating() float64 {
+	if x != nil {
+		return x.SkillRating
+	}
+	return 0
+}
+
 var File_proto_master_server_proto protoreflect.FileDescriptor
 
 var file_proto_master_server_proto_rawDesc = []byte{
@@ -352,6 +657,8 @@ var file_proto_master_server_proto_rawDesc = []byte{
 	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x71, 0x75, 0x61,
 	0x72, 0x6b, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
 	0x21, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65,
 	0x2f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x1a, 0x1a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74,
@@ -361,8 +668,8 @@ var file_proto_master_server_proto_rawDesc = []byte{
 	0x65, 0x77, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69,
 	0x74, 0x69, 0x76, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
-	0x0d, 0x6e, 0x65, 0x77, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0xb9,
-	0x02, 0x0a, 0x13, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d,
+	0x0d, 0x6e, 0x65, 0x77, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x97,
+	0x07, 0x0a, 0x13, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d,
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x51, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
 	0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x71, 0x75, 0x61,
 	0x72, 0x6b, 0x2e, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d,
@@ -373,38 +680,111 @@ var file_proto_master_server_proto_rawDesc = []byte{
 	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76,
 	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x6c,
 	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x6c, 0x6c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x3a, 0x0a, 0x14, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4e, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x71, 0x75,
+	0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x3a, 0x0a, 0x14, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65,
 	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x22,
 	0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x49, 0x44, 0x1a, 0x3b, 0x0a, 0x0e, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69,
-	0x74, 0x69, 0x76, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x42,
-	0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xb7, 0x01, 0x0a, 0x10, 0x47,
-	0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x4b, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
-	0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x1a, 0x56, 0x0a, 0x09,
-	0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x6f, 0x6f,
-	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e,
-	0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x04,
-	0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x32, 0xa1, 0x01, 0x0a, 0x0c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x54, 0x0a, 0x12, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x71, 0x75,
-	0x61, 0x72, 0x6b, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x47, 0x61, 0x6d, 0x65,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
-	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x06, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x47, 0x61,
-	0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x28, 0x01, 0x42, 0x0d, 0x5a, 0x0b, 0x71, 0x75, 0x61, 0x72,
-	0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x49, 0x44, 0x1a, 0xcd, 0x02, 0x0a, 0x0e, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d,
+	0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d,
+	0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72,
+	0x49, 0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x12, 0x32, 0x0a, 0x14, 0x72, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x54, 0x4c, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x54, 0x4c, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x74, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x74, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x56, 0x0a, 0x09,
+	0x75, 0x73, 0x65, 0x72, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x38, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
+	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x54,
+	0x65, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54,
+	0x65, 0x61, 0x6d, 0x73, 0x1a, 0x3c, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x54, 0x65, 0x61, 0x6d,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0xf8, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x18,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x74, 0x6c, 0x4d,
+	0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x74, 0x6c,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x57, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x65,
+	0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x71, 0x75, 0x61, 0x72,
+	0x6b, 0x2e, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x1a,
+	0x3c, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xe1, 0x05, 0x0a, 0x10, 0x47, 0x61, 0x6d,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a,
+	0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x47,
+	0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x0d, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x73,
+	0x12, 0x53, 0x0a, 0x10, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x71, 0x75, 0x61,
+	0x72, 0x6b, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x10, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x48, 0x0a, 0x0c, 0x73, 0x65, 0x74, 0x52, 0x6f, 0x6f, 0x6d,
+	0x46, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x71, 0x75,
+	0x61, 0x72, 0x6b, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x46, 0x6c, 0x61, 0x67,
+	0x73, 0x52, 0x0c, 0x73, 0x65, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x1a,
+	0x80, 0x01, 0x0a, 0x09, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x29, 0x0a,
+	0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x71, 0x75,
+	0x61, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x73, 0x1a, 0xc0, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x46, 0x6c,
+	0x61, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x04, 0x6f,
+	0x70, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x34, 0x0a, 0x07, 0x76,
+	0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c,
+	0x65, 0x12, 0x32, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x6c,
+	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x1a, 0x75, 0x0a, 0x0f, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6b,
+	0x69, 0x6c, 0x6c, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0b, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x32, 0xa1, 0x01, 0x0a,
+	0x0c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x54, 0x0a,
+	0x12, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e,
+	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x28, 0x01,
+	0x42, 0x0d, 0x5a, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -419,34 +799,48 @@ func file_proto_master_server_proto_rawDescGZIP() []byte {
 	return file_proto_master_server_proto_rawDescData
 }
 
-var file_proto_master_server_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_master_server_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_proto_master_server_proto_goTypes = []interface{}{
 	(*RegisterGameServerRequest)(nil),                // 0: quark.RegisterGameServerRequest
 	(*MasterServerMessage)(nil),                      // 1: quark.MasterServerMessage
 	(*GameServerStatus)(nil),                         // 2: quark.GameServerStatus
 	(*MasterServerMessage_GameServerRegistered)(nil), // 3: quark.MasterServerMessage.GameServerRegistered
 	(*MasterServerMessage_RoomAllocation)(nil),       // 4: quark.MasterServerMessage.RoomAllocation
-	(*GameServerStatus_RoomState)(nil),               // 5: quark.GameServerStatus.RoomState
-	(*primitive.GameServer)(nil),                     // 6: quark.primitive.GameServer
-	(*primitive.Room)(nil),                           // 7: quark.primitive.Room
-	(*emptypb.Empty)(nil),                            // 8: google.protobuf.Empty
+	(*MasterServerMessage_SeatReservation)(nil),      // 5: quark.MasterServerMessage.SeatReservation
+	nil,                                      // 6: quark.MasterServerMessage.RoomAllocation.UserTeamsEntry
+	nil,                                      // 7: quark.MasterServerMessage.SeatReservation.UserTeamsEntry
+	(*GameServerStatus_RoomState)(nil),       // 8: quark.GameServerStatus.RoomState
+	(*GameServerStatus_SetRoomFlags)(nil),    // 9: quark.GameServerStatus.SetRoomFlags
+	(*GameServerStatus_BackfillRequest)(nil), // 10: quark.GameServerStatus.BackfillRequest
+	(*primitive.GameServer)(nil),             // 11: quark.primitive.GameServer
+	(*primitive.Room)(nil),                   // 12: quark.primitive.Room
+	(*wrapperspb.BoolValue)(nil),             // 13: google.protobuf.BoolValue
+	(*emptypb.Empty)(nil),                    // 14: google.protobuf.Empty
 }
 var file_proto_master_server_proto_depIdxs = []int32{
-	6, // 0: quark.RegisterGameServerRequest.newGameServer:type_name -> quark.primitive.GameServer
-	3, // 1: quark.MasterServerMessage.registered:type_name -> quark.MasterServerMessage.GameServerRegistered
-	4, // 2: quark.MasterServerMessage.allocation:type_name -> quark.MasterServerMessage.RoomAllocation
-	5, // 3: quark.GameServerStatus.updateRoomState:type_name -> quark.GameServerStatus.RoomState
-	7, // 4: quark.MasterServerMessage.RoomAllocation.room:type_name -> quark.primitive.Room
-	7, // 5: quark.GameServerStatus.RoomState.room:type_name -> quark.primitive.Room
-	0, // 6: quark.MasterServer.RegisterGameServer:input_type -> quark.RegisterGameServerRequest
-	2, // 7: quark.MasterServer.Update:input_type -> quark.GameServerStatus
-	1, // 8: quark.MasterServer.RegisterGameServer:output_type -> quark.MasterServerMessage
-	8, // 9: quark.MasterServer.Update:output_type -> google.protobuf.Empty
-	8, // [8:10] is the sub-list for method output_type
-	6, // [6:8] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	11, // 0: quark.RegisterGameServerRequest.newGameServer:type_name -> quark.primitive.GameServer
+	3,  // 1: quark.MasterServerMessage.registered:type_name -> quark.MasterServerMessage.GameServerRegistered
+	4,  // 2: quark.MasterServerMessage.allocation:type_name -> quark.MasterServerMessage.RoomAllocation
+	5,  // 3: quark.MasterServerMessage.reservation:type_name -> quark.MasterServerMessage.SeatReservation
+	8,  // 4: quark.GameServerStatus.updateRoomState:type_name -> quark.GameServerStatus.RoomState
+	10, // 5: quark.GameServerStatus.backfillRequests:type_name -> quark.GameServerStatus.BackfillRequest
+	9,  // 6: quark.GameServerStatus.setRoomFlags:type_name -> quark.GameServerStatus.SetRoomFlags
+	12, // 7: quark.MasterServerMessage.RoomAllocation.room:type_name -> quark.primitive.Room
+	6,  // 8: quark.MasterServerMessage.RoomAllocation.userTeams:type_name -> quark.MasterServerMessage.RoomAllocation.UserTeamsEntry
+	7,  // 9: quark.MasterServerMessage.SeatReservation.userTeams:type_name -> quark.MasterServerMessage.SeatReservation.UserTeamsEntry
+	12, // 10: quark.GameServerStatus.RoomState.room:type_name -> quark.primitive.Room
+	13, // 11: quark.GameServerStatus.SetRoomFlags.open:type_name -> google.protobuf.BoolValue
+	13, // 12: quark.GameServerStatus.SetRoomFlags.visible:type_name -> google.protobuf.BoolValue
+	13, // 13: quark.GameServerStatus.SetRoomFlags.locked:type_name -> google.protobuf.BoolValue
+	0,  // 14: quark.MasterServer.RegisterGameServer:input_type -> quark.RegisterGameServerRequest
+	2,  // 15: quark.MasterServer.Update:input_type -> quark.GameServerStatus
+	1,  // 16: quark.MasterServer.RegisterGameServer:output_type -> quark.MasterServerMessage
+	14, // 17: quark.MasterServer.Update:output_type -> google.protobuf.Empty
+	16, // [16:18] is the sub-list for method output_type
+	14, // [14:16] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_proto_master_server_proto_init() }
@@ -516,6 +910,18 @@ func file_proto_master_server_proto_init() {
 			}
 		}
 		file_proto_master_server_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MasterServerMessage_SeatReservation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_master_server_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GameServerStatus_RoomState); i {
 			case 0:
 				return &v.state
@@ -527,10 +933,35 @@ func file_proto_master_server_proto_init() {
 				return nil
 			}
 		}
+		file_proto_master_server_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameServerStatus_SetRoomFlags); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_master_server_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameServerStatus_BackfillRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_proto_master_server_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*MasterServerMessage_Registered)(nil),
 		(*MasterServerMessage_Allocation)(nil),
+		(*MasterServerMessage_Reservation)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -538,7 +969,7 @@ func file_proto_master_server_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_master_server_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,555 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.17.3
+// source: proto/matchmaker.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	primitive "quark/proto/primitive"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FindMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the users of the party
+	UserIDs     []string `protobuf:"bytes,1,rep,name=userIDs,proto3" json:"userIDs,omitempty"`
+	Mode        string   `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	SkillRating float64  `protobuf:"fixed64,3,opt,name=skillRating,proto3" json:"skillRating,omitempty"`
+	// empty to choose the region by latencyMillis
+	Region        string            `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	LatencyMillis map[string]uint32 `protobuf:"bytes,5,rep,name=latencyMillis,proto3" json:"latencyMillis,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *FindMatchRequest) Reset() {
+	*x = FindMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_matchmaker_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMatchRequest) ProtoMessage() {}
+
+func (x *FindMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaker_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMatchRequest.ProtoReflect.Descriptor instead.
+func (*FindMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FindMatchRequest) GetUserIDs() []string {
+	if x != nil {
+		return x.UserIDs
+	}
+	return nil
+}
+
+func (x *FindMatchRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *FindMatchRequest) GetSkillRating() float64 {
+	if x != nil {
+		return x.SkillRating
+	}
+	return 0
+}
+
+func (x *FindMatchRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *FindMatchRequest) GetLatencyMillis() map[string]uint32 {
+	if x != nil {
+		return x.LatencyMillis
+	}
+	return nil
+}
+
+type MatchmakingEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Message:
+	//	*MatchmakingEvent_OnQueued
+	//	*MatchmakingEvent_OnMatchFound
+	Message isMatchmakingEvent_Message `protobuf_oneof:"message"`
+}
+
+func (x *MatchmakingEvent) Reset() {
+	*x = MatchmakingEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_matchmaker_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchmakingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchmakingEvent) ProtoMessage() {}
+
+func (x *MatchmakingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaker_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchmakingEvent.ProtoReflect.Descriptor instead.
+func (*MatchmakingEvent) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaker_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *MatchmakingEvent) GetMessage() isMatchmakingEvent_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *MatchmakingEvent) GetOnQueued() *MatchmakingEvent_Queued {
+	if x, ok := x.GetMessage().(*MatchmakingEvent_OnQueued); ok {
+		return x.OnQueued
+	}
+	return nil
+}
+
+func (x *MatchmakingEvent) GetOnMatchFound() *MatchmakingEvent_MatchFound {
+	if x, ok := x.GetMessage().(*MatchmakingEvent_OnMatchFound); ok {
+		return x.OnMatchFound
+	}
+	return nil
+}
+
+type isMatchmakingEvent_Message interface {
+	isMatchmakingEvent_Message()
+}
+
+type MatchmakingEvent_OnQueued struct {
+	OnQueued *MatchmakingEvent_Queued `protobuf:"bytes,1,opt,name=onQueued,proto3,oneof"`
+}
+
+type MatchmakingEvent_OnMatchFound struct {
+	OnMatchFound *MatchmakingEvent_MatchFound `protobuf:"bytes,2,opt,name=onMatchFound,proto3,oneof"`
+}
+
+func (*MatchmakingEvent_OnQueued) isMatchmakingEvent_Message() {}
+
+func (*MatchmakingEvent_OnMatchFound) isMatchmakingEvent_Message() {}
+
+type Seat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// 0 if the mode has no teams, otherwise from 1
+	Team uint32 `protobuf:"varint,2,opt,name=team,proto3" json:"team,omitempty"`
+}
+
+func (x *Seat) Reset() {
+	*x = Seat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_matchmaker_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Seat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Seat) ProtoMessage() {}
+
+func (x *Seat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaker_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Seat.ProtoReflect.Descriptor instead.
+func (*Seat) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Seat) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *Seat) GetTeam() uint32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
+type MatchmakingEvent_Queued struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TicketID string `protobuf:"bytes,1,opt,name=ticketID,proto3" json:"ticketID,omitempty"`
+}
+
+func (x *MatchmakingEvent_Queued) Reset() {
+	*x = MatchmakingEvent_Queued{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_matchmaker_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchmakingEvent_Queued) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchmakingEvent_Queued) ProtoMessage() {}
+
+func (x *MatchmakingEvent_Queued) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaker_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchmakingEvent_Queued.ProtoReflect.Descriptor instead.
+func (*MatchmakingEvent_Queued) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaker_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *MatchmakingEvent_Queued) GetTicketID() string {
+	if x != nil {
+		return x.TicketID
+	}
+	return ""
+}
+
+type MatchmakingEvent_MatchFound struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server *primitive.GameServer `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	RoomID uint64                `protobuf:"varint,2,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Region string                `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	// the seats of the users of the ticket
+	Seats []*Seat `protobuf:"bytes,4,rep,name=seats,proto3" json:"seats,omitempty"`
+	// true if the room is running and the users fill the seats left
+	Backfill bool `protobuf:"varint,5,opt,name=backfill,proto3" json:"backfill,omitempty"`
+}
+
+func (x *MatchmakingEvent_MatchFound) Reset() {
+	*x = MatchmakingEvent_MatchFound{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_matchmaker_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchmakingEvent_MatchFound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchmakingEvent_MatchFound) ProtoMessage() {}
+
+func (x *MatchmakingEvent_MatchFound) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaker_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchmakingEvent_MatchFound.ProtoReflect.Descriptor instead.
+func (*MatchmakingEvent_MatchFound) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaker_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *MatchmakingEvent_MatchFound) GetServer() *primitive.GameServer {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *MatchmakingEvent_MatchFound) GetRoomID() uint64 {
+	if x != nil {
+		return x.RoomID
+	}
+	return 0
+}
+
+func (x *MatchmakingEvent_MatchFound) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *MatchmakingEvent_MatchFound) GetSeats() []*Seat {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *MatchmakingEvent_MatchFound) GetBackfill() bool {
+	if x != nil {
+		return x.Backfill
+	}
+	return false
+}
+
+var File_proto_matchmaker_proto protoreflect.FileDescriptor
+
+var file_proto_matchmaker_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x6d, 0x61, 0x6b,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x1a,
+	0x21, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65,
+	0x2f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x8e, 0x02, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x52, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x73, 0x6b, 0x69, 0x6c,
+	0x6c, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12,
+	0x50, 0x0a, 0x0d, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x46,
+	0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0d, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x1a, 0x40, 0x0a, 0x12, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xfe, 0x02, 0x0a, 0x10, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x6d, 0x61, 0x6b,
+	0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x08, 0x6f, 0x6e, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x71, 0x75, 0x61,
+	0x72, 0x6b, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x6d, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x48, 0x00, 0x52, 0x08, 0x6f, 0x6e,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x0c, 0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x6d, 0x61, 0x6b, 0x69, 0x6e, 0x67,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x46, 0x6f, 0x75, 0x6e, 0x64,
+	0x48, 0x00, 0x52, 0x0c, 0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x46, 0x6f, 0x75, 0x6e, 0x64,
+	0x1a, 0x24, 0x0a, 0x06, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x49, 0x44, 0x1a, 0xb0, 0x01, 0x0a, 0x0a, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x70, 0x72,
+	0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f,
+	0x6f, 0x6d, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d,
+	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x65,
+	0x61, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x71, 0x75, 0x61, 0x72,
+	0x6b, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x52, 0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x32, 0x0a, 0x04, 0x53, 0x65, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x32, 0x4d, 0x0a, 0x0a, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x6d, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x3f, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x17, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x46, 0x69, 0x6e, 0x64,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x6d, 0x61, 0x6b, 0x69, 0x6e, 0x67,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x0d, 0x5a, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x6b,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_matchmaker_proto_rawDescOnce sync.Once
+	file_proto_matchmaker_proto_rawDescData = file_proto_matchmaker_proto_rawDesc
+)
+
+func file_proto_matchmaker_proto_rawDescGZIP() []byte {
+	file_proto_matchmaker_proto_rawDescOnce.Do(func() {
+		file_proto_matchmaker_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_matchmaker_proto_rawDescData)
+	})
+	return file_proto_matchmaker_proto_rawDescData
+}
+
+var file_proto_matchmaker_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_matchmaker_proto_goTypes = []interface{}{
+	(*FindMatchRequest)(nil),            // 0: quark.FindMatchRequest
+	(*MatchmakingEvent)(nil),            // 1: quark.MatchmakingEvent
+	(*Seat)(nil),                        // 2: quark.Seat
+	nil,                                 // 3: quark.FindMatchRequest.LatencyMillisEntry
+	(*MatchmakingEvent_Queued)(nil),     // 4: quark.MatchmakingEvent.Queued
+	(*MatchmakingEvent_MatchFound)(nil), // 5: quark.MatchmakingEvent.MatchFound
+	(*primitive.GameServer)(nil),        // 6: quark.primitive.GameServer
+}
+var file_proto_matchmaker_proto_depIdxs = []int32{
+	3, // 0: quark.FindMatchRequest.latencyMillis:type_name -> quark.FindMatchRequest.LatencyMillisEntry
+	4, // 1: quark.MatchmakingEvent.onQueued:type_name -> quark.MatchmakingEvent.Queued
+	5, // 2: quark.MatchmakingEvent.onMatchFound:type_name -> quark.MatchmakingEvent.MatchFound
+	6, // 3: quark.MatchmakingEvent.MatchFound.server:type_name -> quark.primitive.GameServer
+	2, // 4: quark.MatchmakingEvent.MatchFound.seats:type_name -> quark.Seat
+	0, // 5: quark.Matchmaker.FindMatch:input_type -> quark.FindMatchRequest
+	1, // 6: quark.Matchmaker.FindMatch:output_type -> quark.MatchmakingEvent
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_matchmaker_proto_init() }
+func file_proto_matchmaker_proto_init() {
+	if File_proto_matchmaker_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_matchmaker_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindMatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_matchmaker_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchmakingEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_matchmaker_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Seat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_matchmaker_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchmakingEvent_Queued); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_matchmaker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchmakingEvent_MatchFound); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_matchmaker_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*MatchmakingEvent_OnQueued)(nil),
+		(*MatchmakingEvent_OnMatchFound)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_matchmaker_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_matchmaker_proto_goTypes,
+		DependencyIndexes: file_proto_matchmaker_proto_depIdxs,
+		MessageInfos:      file_proto_matchmaker_proto_msgTypes,
+	}.Build()
+	File_proto_matchmaker_proto = out.File
+	file_proto_matchmaker_proto_rawDesc = nil
+	file_proto_matchmaker_proto_goTypes = nil
+	file_proto_matchmaker_proto_depIdxs = nil
+}
@@ -0,0 +1,130 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// MatchmakerClient is the client API for Matchmaker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MatchmakerClient interface {
+	// queues the ticket until it is matched; canceling the stream cancels the ticket
+	FindMatch(ctx context.Context, in *FindMatchRequest, opts ...grpc.CallOption) (Matchmaker_FindMatchClient, error)
+}
+
+type matchmakerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatchmakerClient(cc grpc.ClientConnInterface) MatchmakerClient {
+	return &matchmakerClient{cc}
+}
+
+func (c *matchmakerClient) FindMatch(ctx context.Context, in *FindMatchRequest, opts ...grpc.CallOption) (Matchmaker_FindMatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Matchmaker_ServiceDesc.Streams[0], "/quark.Matchmaker/FindMatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &matchmakerFindMatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Matchmaker_FindMatchClient interface {
+	Recv() (*MatchmakingEvent, error)
+	grpc.ClientStream
+}
+
+type matchmakerFindMatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *matchmakerFindMatchClient) Recv() (*MatchmakingEvent, error) {
+	m := new(MatchmakingEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MatchmakerServer is the server API for Matchmaker service.
+// All implementations must embed UnimplementedMatchmakerServer
+// for forward compatibility
+type MatchmakerServer interface {
+	// queues the ticket until it is matched; canceling the stream cancels the ticket
+	FindMatch(*FindMatchRequest, Matchmaker_FindMatchServer) error
+	mustEmbedUnimplementedMatchmakerServer()
+}
+
+// UnimplementedMatchmakerServer must be embedded to have forward compatible implementations.
+type UnimplementedMatchmakerServer struct {
+}
+
+func (UnimplementedMatchmakerServer) FindMatch(*FindMatchRequest, Matchmaker_FindMatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindMatch not implemented")
+}
+func (UnimplementedMatchmakerServer) mustEmbedUnimplementedMatchmakerServer() {}
+
+// UnsafeMatchmakerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatchmakerServer will
+// result in compilation errors.
+type UnsafeMatchmakerServer interface {
+	mustEmbedUnimplementedMatchmakerServer()
+}
+
+func RegisterMatchmakerServer(s grpc.ServiceRegistrar, srv MatchmakerServer) {
+	s.RegisterService(&Matchmaker_ServiceDesc, srv)
+}
+
+func _Matchmaker_FindMatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindMatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchmakerServer).FindMatch(m, &matchmakerFindMatchServer{stream})
+}
+
+type Matchmaker_FindMatchServer interface {
+	Send(*MatchmakingEvent) error
+	grpc.ServerStream
+}
+
+type matchmakerFindMatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *matchmakerFindMatchServer) Send(m *MatchmakingEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Matchmaker_ServiceDesc is the grpc.ServiceDesc for Matchmaker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Matchmaker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "quark.Matchmaker",
+	HandlerType: (*MatchmakerServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FindMatch",
+			Handler:       _Matchmaker_FindMatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/matchmaker.proto",
+}
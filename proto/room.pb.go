@@ -20,12 +20,211 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ClientMessage_SendMessageCommand_Target int32
+
+const (
+	ClientMessage_SendMessageCommand_ROOM ClientMessage_SendMessageCommand_Target = 0
+	ClientMessage_SendMessageCommand_TEAM ClientMessage_SendMessageCommand_Target = 1
+)
+
+// Enum value maps for ClientMessage_SendMessageCommand_Target.
+var (
+	ClientMessage_SendMessageCommand_Target_name = map[int32]string{
+		0: "ROOM",
+		1: "TEAM",
+	}
+	ClientMessage_SendMessageCommand_Target_value = map[string]int32{
+		"ROOM": 0,
+		"TEAM": 1,
+	}
+)
+
+func (x ClientMessage_SendMessageCommand_Target) Enum() *ClientMessage_SendMessageCommand_Target {
+	p := new(ClientMessage_SendMessageCommand_Target)
+	*p = x
+	return p
+}
+
+func (x ClientMessage_SendMessageCommand_Target) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ClientMessage_SendMessageCommand_Target) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_room_proto_enumTypes[0].Descriptor()
+}
+
+func (ClientMessage_SendMessageCommand_Target) Type() protoreflect.EnumType {
+	return &file_proto_room_proto_enumTypes[0]
+}
+
+func (x ClientMessage_SendMessageCommand_Target) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ClientMessage_SendMessageCommand_Target.Descriptor instead.
+func (ClientMessage_SendMessageCommand_Target) EnumDescriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 1, 0}
+}
+
+type ServerMessage_CommandError_Code int32
+
+const (
+	ServerMessage_CommandError_UNKNOWN                  ServerMessage_CommandError_Code = 0
+	ServerMessage_CommandError_ROOM_NOT_FOUND           ServerMessage_CommandError_Code = 1
+	ServerMessage_CommandError_ROOM_FULL                ServerMessage_CommandError_Code = 2
+	ServerMessage_CommandError_ROOM_CLOSED              ServerMessage_CommandError_Code = 3
+	ServerMessage_CommandError_NOT_MEMBER               ServerMessage_CommandError_Code = 4
+	ServerMessage_CommandError_ALREADY_MEMBER           ServerMessage_CommandError_Code = 5
+	ServerMessage_CommandError_FORBIDDEN                ServerMessage_CommandError_Code = 8
+	ServerMessage_CommandError_INVALID_PAYLOAD          ServerMessage_CommandError_Code = 9
+	ServerMessage_CommandError_REJECTED                 ServerMessage_CommandError_Code = 10
+	ServerMessage_CommandError_TEAM_NOT_FOUND           ServerMessage_CommandError_Code = 11
+	ServerMessage_CommandError_TEAM_FULL                ServerMessage_CommandError_Code = 12
+	ServerMessage_CommandError_NOT_ROOM_MASTER          ServerMessage_CommandError_Code = 13
+	ServerMessage_CommandError_REQUEST_TIMEOUT          ServerMessage_CommandError_Code = 14
+	ServerMessage_CommandError_REQUEST_TARGET_NOT_FOUND ServerMessage_CommandError_Code = 15
+	ServerMessage_CommandError_REQUEST_TARGET_LEFT      ServerMessage_CommandError_Code = 16
+	ServerMessage_CommandError_REQUEST_DUPLICATED       ServerMessage_CommandError_Code = 17
+	ServerMessage_CommandError_REQUEST_NOT_HANDLED      ServerMessage_CommandError_Code = 18
+	ServerMessage_CommandError_REQUEST_NOT_PENDING      ServerMessage_CommandError_Code = 19
+)
+
+// Enum value maps for ServerMessage_CommandError_Code.
+var (
+	ServerMessage_CommandError_Code_name = map[int32]string{
+		0:  "UNKNOWN",
+		1:  "ROOM_NOT_FOUND",
+		2:  "ROOM_FULL",
+		3:  "ROOM_CLOSED",
+		4:  "NOT_MEMBER",
+		5:  "ALREADY_MEMBER",
+		8:  "FORBIDDEN",
+		9:  "INVALID_PAYLOAD",
+		10: "REJECTED",
+		11: "TEAM_NOT_FOUND",
+		12: "TEAM_FULL",
+		13: "NOT_ROOM_MASTER",
+		14: "REQUEST_TIMEOUT",
+		15: "REQUEST_TARGET_NOT_FOUND",
+		16: "REQUEST_TARGET_LEFT",
+		17: "REQUEST_DUPLICATED",
+		18: "REQUEST_NOT_HANDLED",
+		19: "REQUEST_NOT_PENDING",
+	}
+	ServerMessage_CommandError_Code_value = map[string]int32{
+		"UNKNOWN":                  0,
+		"ROOM_NOT_FOUND":           1,
+		"ROOM_FULL":                2,
+		"ROOM_CLOSED":              3,
+		"NOT_MEMBER":               4,
+		"ALREADY_MEMBER":           5,
+		"FORBIDDEN":                8,
+		"INVALID_PAYLOAD":          9,
+		"REJECTED":                 10,
+		"TEAM_NOT_FOUND":           11,
+		"TEAM_FULL":                12,
+		"NOT_ROOM_MASTER":          13,
+		"REQUEST_TIMEOUT":          14,
+		"REQUEST_TARGET_NOT_FOUND": 15,
+		"REQUEST_TARGET_LEFT":      16,
+		"REQUEST_DUPLICATED":       17,
+		"REQUEST_NOT_HANDLED":      18,
+		"REQUEST_NOT_PENDING":      19,
+	}
+)
+
+func (x ServerMessage_CommandError_Code) Enum() *ServerMessage_CommandError_Code {
+	p := new(ServerMessage_CommandError_Code)
+	*p = x
+	return p
+}
+
+func (x ServerMessage_CommandError_Code) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServerMessage_CommandError_Code) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_room_proto_enumTypes[1].Descriptor()
+}
+
+func (ServerMessage_CommandError_Code) Type() protoreflect.EnumType {
+	return &file_proto_room_proto_enumTypes[1]
+}
+
+func (x ServerMessage_CommandError_Code) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServerMessage_CommandError_Code.Descriptor instead.
+func (ServerMessage_CommandError_Code) EnumDescriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 0, 0}
+}
+
+type ServerMessage_LeaveRoom_Reason int32
+
+const (
+	ServerMessage_LeaveRoom_VOLUNTARY       ServerMessage_LeaveRoom_Reason = 0
+	ServerMessage_LeaveRoom_DISCONNECTED    ServerMessage_LeaveRoom_Reason = 1
+	ServerMessage_LeaveRoom_TIMED_OUT       ServerMessage_LeaveRoom_Reason = 2
+	ServerMessage_LeaveRoom_KICKED          ServerMessage_LeaveRoom_Reason = 3
+	ServerMessage_LeaveRoom_ROOM_CLOSED     ServerMessage_LeaveRoom_Reason = 4
+	ServerMessage_LeaveRoom_SERVER_SHUTDOWN ServerMessage_LeaveRoom_Reason = 5
+)
+
+// Enum value maps for ServerMessage_LeaveRoom_Reason.
+var (
+	ServerMessage_LeaveRoom_Reason_name = map[int32]string{
+		0: "VOLUNTARY",
+		1: "DISCONNECTED",
+		2: "TIMED_OUT",
+		3: "KICKED",
+		4: "ROOM_CLOSED",
+		5: "SERVER_SHUTDOWN",
+	}
+	ServerMessage_LeaveRoom_Reason_value = map[string]int32{
+		"VOLUNTARY":       0,
+		"DISCONNECTED":    1,
+		"TIMED_OUT":       2,
+		"KICKED":          3,
+		"ROOM_CLOSED":     4,
+		"SERVER_SHUTDOWN": 5,
+	}
+)
+
+func (x ServerMessage_LeaveRoom_Reason) Enum() *ServerMessage_LeaveRoom_Reason {
+	p := new(ServerMessage_LeaveRoom_Reason)
+	*p = x
+	return p
+}
+
+func (x ServerMessage_LeaveRoom_Reason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServerMessage_LeaveRoom_Reason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_room_proto_enumTypes[2].Descriptor()
+}
+
+func (ServerMessage_LeaveRoom_Reason) Type() protoreflect.EnumType {
+	return &file_proto_room_proto_enumTypes[2]
+}
+
+func (x ServerMessage_LeaveRoom_Reason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServerMessage_LeaveRoom_Reason.Descriptor instead.
+func (ServerMessage_LeaveRoom_Reason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 8, 0}
+}
+
 type CreateRoomRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RoomName string `protobuf:"bytes,1,opt,name=roomName,proto3" json:"roomName,omitempty"`
+	RoomName    string       `protobuf:"bytes,1,opt,name=roomName,proto3" json:"roomName,omitempty"`
+	RoomOptions *RoomOptions `protobuf:"bytes,2,opt,name=roomOptions,proto3" json:"roomOptions,omitempty"`
 }
 
 func (x *CreateRoomRequest) Reset() {
@@ -67,6 +266,165 @@ func (x *CreateRoomRequest) GetRoomName() string {
 	return ""
 }
 
+func (x *CreateRoomRequest) GetRoomOptions() *RoomOptions {
+	if x != nil {
+		return x.RoomOptions
+	}
+	return nil
+}
+
+type RoomOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TeamCount uint32 `protobuf:"varint,1,opt,name=teamCount,proto3" json:"teamCount,omitempty"`
+	TeamSize  uint32 `protobuf:"varint,2,opt,name=teamSize,proto3" json:"teamSize,omitempty"`
+	// selects the message interceptors configured on the server
+	RoomType string `protobuf:"bytes,3,opt,name=roomType,proto3" json:"roomType,omitempty"`
+	// 0 means no limit
+	MaxActors uint32 `protobuf:"varint,4,opt,name=maxActors,proto3" json:"maxActors,omitempty"`
+	// custom room properties
+	Properties map[string]string `protobuf:"bytes,5,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the keys of the properties shown in the lobby and matched by JoinRandomRoom
+	LobbyProperties []string `protobuf:"bytes,6,rep,name=lobbyProperties,proto3" json:"lobbyProperties,omitempty"`
+	GameMode        string   `protobuf:"bytes,7,opt,name=gameMode,proto3" json:"gameMode,omitempty"`
+	// allocates the room on a game server in the region if set
+	Region string `protobuf:"bytes,8,opt,name=region,proto3" json:"region,omitempty"`
+	// hidden rooms are not listed in the lobby
+	Hidden bool `protobuf:"varint,9,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	Locked bool `protobuf:"varint,10,opt,name=locked,proto3" json:"locked,omitempty"`
+	// the lobby which lists the room; empty for the default lobby
+	Lobby string `protobuf:"bytes,11,opt,name=lobby,proto3" json:"lobby,omitempty"`
+	// the users holding seats under maxActors until they join or the seats expire
+	ReservedUserIDs []string `protobuf:"bytes,12,rep,name=reservedUserIDs,proto3" json:"reservedUserIDs,omitempty"`
+	// puts the users into their teams when they join
+	UserTeams map[string]uint32 `protobuf:"bytes,13,rep,name=userTeams,proto3" json:"userTeams,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *RoomOptions) Reset() {
+	*x = RoomOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomOptions) ProtoMessage() {}
+
+func (x *RoomOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomOptions.ProtoReflect.Descriptor instead.
+func (*RoomOptions) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RoomOptions) GetTeamCount() uint32 {
+	if x != nil {
+		return x.TeamCount
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetTeamSize() uint32 {
+	if x != nil {
+		return x.TeamSize
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetRoomType() string {
+	if x != nil {
+		return x.RoomType
+	}
+	return ""
+}
+
+func (x *RoomOptions) GetMaxActors() uint32 {
+	if x != nil {
+		return x.MaxActors
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *RoomOptions) GetLobbyProperties() []string {
+	if x != nil {
+		return x.LobbyProperties
+	}
+	return nil
+}
+
+func (x *RoomOptions) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *RoomOptions) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RoomOptions) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+func (x *RoomOptions) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *RoomOptions) GetLobby() string {
+	if x != nil {
+		return x.Lobby
+	}
+	return ""
+}
+
+func (x *RoomOptions) GetReservedUserIDs() []string {
+	if x != nil {
+		return x.ReservedUserIDs
+	}
+	return nil
+}
+
+func (x *RoomOptions) GetUserTeams() map[string]uint32 {
+	if x != nil {
+		return x.UserTeams
+	}
+	return nil
+}
+
 type CreateRoomResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -79,7 +437,7 @@ type CreateRoomResponse struct {
 func (x *CreateRoomResponse) Reset() {
 	*x = CreateRoomResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[1]
+		mi := &file_proto_room_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -92,7 +450,7 @@ func (x *CreateRoomResponse) String() string {
 func (*CreateRoomResponse) ProtoMessage() {}
 
 func (x *CreateRoomResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[1]
+	mi := &file_proto_room_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -105,7 +463,7 @@ func (x *CreateRoomResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRoomResponse.ProtoReflect.Descriptor instead.
 func (*CreateRoomResponse) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{1}
+	return file_proto_room_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateRoomResponse) GetRoomID() uint64 {
@@ -127,17 +485,27 @@ type ClientMessage struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// the room which the command is sent to; joinRoom uses its own roomID
+	RoomID uint64 `protobuf:"varint,10,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	// optional ID chosen by the client, echoed on the reply of the command
+	CorrelationID uint64 `protobuf:"varint,11,opt,name=correlationID,proto3" json:"correlationID,omitempty"`
 	// Types that are assignable to Command:
 	//	*ClientMessage_JoinRoom
 	//	*ClientMessage_SendMessage
 	//	*ClientMessage_LeaveRoom
+	//	*ClientMessage_ChangeTeam
+	//	*ClientMessage_SwapTeams
+	//	*ClientMessage_SendRequest
+	//	*ClientMessage_SendResponse
+	//	*ClientMessage_Ping
+	//	*ClientMessage_Pong
 	Command isClientMessage_Command `protobuf_oneof:"command"`
 }
 
 func (x *ClientMessage) Reset() {
 	*x = ClientMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[2]
+		mi := &file_proto_room_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -150,7 +518,7 @@ func (x *ClientMessage) String() string {
 func (*ClientMessage) ProtoMessage() {}
 
 func (x *ClientMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[2]
+	mi := &file_proto_room_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -163,7 +531,21 @@ func (x *ClientMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientMessage.ProtoReflect.Descriptor instead.
 func (*ClientMessage) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{2}
+	return file_proto_room_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ClientMessage) GetRoomID() uint64 {
+	if x != nil {
+		return x.RoomID
+	}
+	return 0
+}
+
+func (x *ClientMessage) GetCorrelationID() uint64 {
+	if x != nil {
+		return x.CorrelationID
+	}
+	return 0
 }
 
 func (m *ClientMessage) GetCommand() isClientMessage_Command {
@@ -194,6 +576,48 @@ func (x *ClientMessage) GetLeaveRoom() *ClientMessage_LeaveRoomCommand {
 	return nil
 }
 
+func (x *ClientMessage) GetChangeTeam() *ClientMessage_ChangeTeamCommand {
+	if x, ok := x.GetCommand().(*ClientMessage_ChangeTeam); ok {
+		return x.ChangeTeam
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetSwapTeams() *ClientMessage_SwapTeamsCommand {
+	if x, ok := x.GetCommand().(*ClientMessage_SwapTeams); ok {
+		return x.SwapTeams
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetSendRequest() *ClientMessage_SendRequestCommand {
+	if x, ok := x.GetCommand().(*ClientMessage_SendRequest); ok {
+		return x.SendRequest
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetSendResponse() *ClientMessage_SendResponseCommand {
+	if x, ok := x.GetCommand().(*ClientMessage_SendResponse); ok {
+		return x.SendResponse
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetPing() *ClientMessage_PingCommand {
+	if x, ok := x.GetCommand().(*ClientMessage_Ping); ok {
+		return x.Ping
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetPong() *ClientMessage_PongCommand {
+	if x, ok := x.GetCommand().(*ClientMessage_Pong); ok {
+		return x.Pong
+	}
+	return nil
+}
+
 type isClientMessage_Command interface {
 	isClientMessage_Command()
 }
@@ -210,12 +634,48 @@ type ClientMessage_LeaveRoom struct {
 	LeaveRoom *ClientMessage_LeaveRoomCommand `protobuf:"bytes,3,opt,name=leaveRoom,proto3,oneof"`
 }
 
+type ClientMessage_ChangeTeam struct {
+	ChangeTeam *ClientMessage_ChangeTeamCommand `protobuf:"bytes,4,opt,name=changeTeam,proto3,oneof"`
+}
+
+type ClientMessage_SwapTeams struct {
+	SwapTeams *ClientMessage_SwapTeamsCommand `protobuf:"bytes,5,opt,name=swapTeams,proto3,oneof"`
+}
+
+type ClientMessage_SendRequest struct {
+	SendRequest *ClientMessage_SendRequestCommand `protobuf:"bytes,6,opt,name=sendRequest,proto3,oneof"`
+}
+
+type ClientMessage_SendResponse struct {
+	SendResponse *ClientMessage_SendResponseCommand `protobuf:"bytes,7,opt,name=sendResponse,proto3,oneof"`
+}
+
+type ClientMessage_Ping struct {
+	Ping *ClientMessage_PingCommand `protobuf:"bytes,8,opt,name=ping,proto3,oneof"`
+}
+
+type ClientMessage_Pong struct {
+	Pong *ClientMessage_PongCommand `protobuf:"bytes,9,opt,name=pong,proto3,oneof"`
+}
+
 func (*ClientMessage_JoinRoom) isClientMessage_Command() {}
 
 func (*ClientMessage_SendMessage) isClientMessage_Command() {}
 
 func (*ClientMessage_LeaveRoom) isClientMessage_Command() {}
 
+func (*ClientMessage_ChangeTeam) isClientMessage_Command() {}
+
+func (*ClientMessage_SwapTeams) isClientMessage_Command() {}
+
+func (*ClientMessage_SendRequest) isClientMessage_Command() {}
+
+func (*ClientMessage_SendResponse) isClientMessage_Command() {}
+
+func (*ClientMessage_Ping) isClientMessage_Command() {}
+
+func (*ClientMessage_Pong) isClientMessage_Command() {}
+
 type Message struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -228,7 +688,7 @@ type Message struct {
 func (x *Message) Reset() {
 	*x = Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[3]
+		mi := &file_proto_room_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -241,7 +701,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[3]
+	mi := &file_proto_room_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,7 +714,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{3}
+	return file_proto_room_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Message) GetCode() uint32 {
@@ -276,20 +736,33 @@ type ServerMessage struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// the room which the event comes from; zero for heartbeats
+	RoomID uint64 `protobuf:"varint,14,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	// the correlationID of the command which the message replies to
+	CorrelationID uint64 `protobuf:"varint,16,opt,name=correlationID,proto3" json:"correlationID,omitempty"`
 	// Types that are assignable to Event:
 	//	*ServerMessage_OnCommandFailed
 	//	*ServerMessage_OnJoinRoomSuccess
 	//	*ServerMessage_OnLeaveRoomSuccess
+	//	*ServerMessage_OnMessageAck
 	//	*ServerMessage_OnMessageReceived
 	//	*ServerMessage_OnJoinRoom
 	//	*ServerMessage_OnLeaveRoom
+	//	*ServerMessage_OnTeamChanged
+	//	*ServerMessage_OnRequestReceived
+	//	*ServerMessage_OnResponseReceived
+	//	*ServerMessage_OnPing
+	//	*ServerMessage_OnPong
+	//	*ServerMessage_OnActorPropertiesChanged
+	//	*ServerMessage_OnRoomClosed
+	//	*ServerMessage_OnBatch
 	Event isServerMessage_Event `protobuf_oneof:"event"`
 }
 
 func (x *ServerMessage) Reset() {
 	*x = ServerMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[4]
+		mi := &file_proto_room_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -302,7 +775,7 @@ func (x *ServerMessage) String() string {
 func (*ServerMessage) ProtoMessage() {}
 
 func (x *ServerMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[4]
+	mi := &file_proto_room_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,7 +788,21 @@ func (x *ServerMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerMessage.ProtoReflect.Descriptor instead.
 func (*ServerMessage) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{4}
+	return file_proto_room_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ServerMessage) GetRoomID() uint64 {
+	if x != nil {
+		return x.RoomID
+	}
+	return 0
+}
+
+func (x *ServerMessage) GetCorrelationID() uint64 {
+	if x != nil {
+		return x.CorrelationID
+	}
+	return 0
 }
 
 func (m *ServerMessage) GetEvent() isServerMessage_Event {
@@ -346,6 +833,13 @@ func (x *ServerMessage) GetOnLeaveRoomSuccess() *ServerMessage_LeaveRoomSuccess
 	return nil
 }
 
+func (x *ServerMessage) GetOnMessageAck() *ServerMessage_MessageAck {
+	if x, ok := x.GetEvent().(*ServerMessage_OnMessageAck); ok {
+		return x.OnMessageAck
+	}
+	return nil
+}
+
 func (x *ServerMessage) GetOnMessageReceived() *ServerMessage_ReceivedMessageEvent {
 	if x, ok := x.GetEvent().(*ServerMessage_OnMessageReceived); ok {
 		return x.OnMessageReceived
@@ -367,11 +861,67 @@ func (x *ServerMessage) GetOnLeaveRoom() *ServerMessage_LeaveRoom {
 	return nil
 }
 
-type isServerMessage_Event interface {
-	isServerMessage_Event()
+func (x *ServerMessage) GetOnTeamChanged() *ServerMessage_TeamChanged {
+	if x, ok := x.GetEvent().(*ServerMessage_OnTeamChanged); ok {
+		return x.OnTeamChanged
+	}
+	return nil
 }
 
-type ServerMessage_OnCommandFailed struct {
+func (x *ServerMessage) GetOnRequestReceived() *ServerMessage_ReceivedRequestEvent {
+	if x, ok := x.GetEvent().(*ServerMessage_OnRequestReceived); ok {
+		return x.OnRequestReceived
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetOnResponseReceived() *ServerMessage_ReceivedResponseEvent {
+	if x, ok := x.GetEvent().(*ServerMessage_OnResponseReceived); ok {
+		return x.OnResponseReceived
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetOnPing() *ServerMessage_Ping {
+	if x, ok := x.GetEvent().(*ServerMessage_OnPing); ok {
+		return x.OnPing
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetOnPong() *ServerMessage_Pong {
+	if x, ok := x.GetEvent().(*ServerMessage_OnPong); ok {
+		return x.OnPong
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetOnActorPropertiesChanged() *ServerMessage_ActorPropertiesChanged {
+	if x, ok := x.GetEvent().(*ServerMessage_OnActorPropertiesChanged); ok {
+		return x.OnActorPropertiesChanged
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetOnRoomClosed() *ServerMessage_RoomClosed {
+	if x, ok := x.GetEvent().(*ServerMessage_OnRoomClosed); ok {
+		return x.OnRoomClosed
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetOnBatch() *ServerMessage_Batch {
+	if x, ok := x.GetEvent().(*ServerMessage_OnBatch); ok {
+		return x.OnBatch
+	}
+	return nil
+}
+
+type isServerMessage_Event interface {
+	isServerMessage_Event()
+}
+
+type ServerMessage_OnCommandFailed struct {
 	// command result
 	OnCommandFailed *ServerMessage_CommandError `protobuf:"bytes,1,opt,name=onCommandFailed,proto3,oneof"`
 }
@@ -384,6 +934,10 @@ type ServerMessage_OnLeaveRoomSuccess struct {
 	OnLeaveRoomSuccess *ServerMessage_LeaveRoomSuccess `protobuf:"bytes,3,opt,name=onLeaveRoomSuccess,proto3,oneof"`
 }
 
+type ServerMessage_OnMessageAck struct {
+	OnMessageAck *ServerMessage_MessageAck `protobuf:"bytes,17,opt,name=onMessageAck,proto3,oneof"`
+}
+
 type ServerMessage_OnMessageReceived struct {
 	OnMessageReceived *ServerMessage_ReceivedMessageEvent `protobuf:"bytes,4,opt,name=onMessageReceived,proto3,oneof"`
 }
@@ -396,18 +950,70 @@ type ServerMessage_OnLeaveRoom struct {
 	OnLeaveRoom *ServerMessage_LeaveRoom `protobuf:"bytes,6,opt,name=onLeaveRoom,proto3,oneof"`
 }
 
+type ServerMessage_OnTeamChanged struct {
+	OnTeamChanged *ServerMessage_TeamChanged `protobuf:"bytes,7,opt,name=onTeamChanged,proto3,oneof"`
+}
+
+type ServerMessage_OnRequestReceived struct {
+	OnRequestReceived *ServerMessage_ReceivedRequestEvent `protobuf:"bytes,8,opt,name=onRequestReceived,proto3,oneof"`
+}
+
+type ServerMessage_OnResponseReceived struct {
+	OnResponseReceived *ServerMessage_ReceivedResponseEvent `protobuf:"bytes,9,opt,name=onResponseReceived,proto3,oneof"`
+}
+
+type ServerMessage_OnPing struct {
+	// heartbeat
+	OnPing *ServerMessage_Ping `protobuf:"bytes,10,opt,name=onPing,proto3,oneof"`
+}
+
+type ServerMessage_OnPong struct {
+	OnPong *ServerMessage_Pong `protobuf:"bytes,11,opt,name=onPong,proto3,oneof"`
+}
+
+type ServerMessage_OnActorPropertiesChanged struct {
+	OnActorPropertiesChanged *ServerMessage_ActorPropertiesChanged `protobuf:"bytes,12,opt,name=onActorPropertiesChanged,proto3,oneof"`
+}
+
+type ServerMessage_OnRoomClosed struct {
+	OnRoomClosed *ServerMessage_RoomClosed `protobuf:"bytes,13,opt,name=onRoomClosed,proto3,oneof"`
+}
+
+type ServerMessage_OnBatch struct {
+	// messages queued within the batch window, in order
+	OnBatch *ServerMessage_Batch `protobuf:"bytes,15,opt,name=onBatch,proto3,oneof"`
+}
+
 func (*ServerMessage_OnCommandFailed) isServerMessage_Event() {}
 
 func (*ServerMessage_OnJoinRoomSuccess) isServerMessage_Event() {}
 
 func (*ServerMessage_OnLeaveRoomSuccess) isServerMessage_Event() {}
 
+func (*ServerMessage_OnMessageAck) isServerMessage_Event() {}
+
 func (*ServerMessage_OnMessageReceived) isServerMessage_Event() {}
 
 func (*ServerMessage_OnJoinRoom) isServerMessage_Event() {}
 
 func (*ServerMessage_OnLeaveRoom) isServerMessage_Event() {}
 
+func (*ServerMessage_OnTeamChanged) isServerMessage_Event() {}
+
+func (*ServerMessage_OnRequestReceived) isServerMessage_Event() {}
+
+func (*ServerMessage_OnResponseReceived) isServerMessage_Event() {}
+
+func (*ServerMessage_OnPing) isServerMessage_Event() {}
+
+func (*ServerMessage_OnPong) isServerMessage_Event() {}
+
+func (*ServerMessage_OnActorPropertiesChanged) isServerMessage_Event() {}
+
+func (*ServerMessage_OnRoomClosed) isServerMessage_Event() {}
+
+func (*ServerMessage_OnBatch) isServerMessage_Event() {}
+
 type ClientMessage_JoinRoomCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -419,7 +1025,7 @@ type ClientMessage_JoinRoomCommand struct {
 func (x *ClientMessage_JoinRoomCommand) Reset() {
 	*x = ClientMessage_JoinRoomCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[5]
+		mi := &file_proto_room_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -432,7 +1038,7 @@ func (x *ClientMessage_JoinRoomCommand) String() string {
 func (*ClientMessage_JoinRoomCommand) ProtoMessage() {}
 
 func (x *ClientMessage_JoinRoomCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[5]
+	mi := &file_proto_room_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -445,7 +1051,7 @@ func (x *ClientMessage_JoinRoomCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientMessage_JoinRoomCommand.ProtoReflect.Descriptor instead.
 func (*ClientMessage_JoinRoomCommand) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{2, 0}
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 0}
 }
 
 func (x *ClientMessage_JoinRoomCommand) GetRoomID() uint64 {
@@ -460,13 +1066,19 @@ type ClientMessage_SendMessageCommand struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message *Message `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Message *Message                                `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Target  ClientMessage_SendMessageCommand_Target `protobuf:"varint,2,opt,name=target,proto3,enum=quark.ClientMessage_SendMessageCommand_Target" json:"target,omitempty"`
+	// a newer message with the same key replaces this one while it is not
+	// yet delivered, e.g. for position updates
+	CoalesceKey string `protobuf:"bytes,3,opt,name=coalesceKey,proto3" json:"coalesceKey,omitempty"`
+	// the server replies with MessageAck once the room has accepted the message
+	Ack bool `protobuf:"varint,4,opt,name=ack,proto3" json:"ack,omitempty"`
 }
 
 func (x *ClientMessage_SendMessageCommand) Reset() {
 	*x = ClientMessage_SendMessageCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[6]
+		mi := &file_proto_room_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -479,7 +1091,7 @@ func (x *ClientMessage_SendMessageCommand) String() string {
 func (*ClientMessage_SendMessageCommand) ProtoMessage() {}
 
 func (x *ClientMessage_SendMessageCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[6]
+	mi := &file_proto_room_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +1104,7 @@ func (x *ClientMessage_SendMessageCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientMessage_SendMessageCommand.ProtoReflect.Descriptor instead.
 func (*ClientMessage_SendMessageCommand) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{2, 1}
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 1}
 }
 
 func (x *ClientMessage_SendMessageCommand) GetMessage() *Message {
@@ -502,6 +1114,27 @@ func (x *ClientMessage_SendMessageCommand) GetMessage() *Message {
 	return nil
 }
 
+func (x *ClientMessage_SendMessageCommand) GetTarget() ClientMessage_SendMessageCommand_Target {
+	if x != nil {
+		return x.Target
+	}
+	return ClientMessage_SendMessageCommand_ROOM
+}
+
+func (x *ClientMessage_SendMessageCommand) GetCoalesceKey() string {
+	if x != nil {
+		return x.CoalesceKey
+	}
+	return ""
+}
+
+func (x *ClientMessage_SendMessageCommand) GetAck() bool {
+	if x != nil {
+		return x.Ack
+	}
+	return false
+}
+
 type ClientMessage_LeaveRoomCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -511,7 +1144,7 @@ type ClientMessage_LeaveRoomCommand struct {
 func (x *ClientMessage_LeaveRoomCommand) Reset() {
 	*x = ClientMessage_LeaveRoomCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[7]
+		mi := &file_proto_room_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -524,7 +1157,7 @@ func (x *ClientMessage_LeaveRoomCommand) String() string {
 func (*ClientMessage_LeaveRoomCommand) ProtoMessage() {}
 
 func (x *ClientMessage_LeaveRoomCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[7]
+	mi := &file_proto_room_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -537,40 +1170,34 @@ func (x *ClientMessage_LeaveRoomCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientMessage_LeaveRoomCommand.ProtoReflect.Descriptor instead.
 func (*ClientMessage_LeaveRoomCommand) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{2, 2}
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 2}
 }
 
-type ServerMessage_CommandError struct {
+type ClientMessage_ChangeTeamCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ErrorCode   string `protobuf:"bytes,1,opt,name=errorCode,proto3" json:"errorCode,omitempty"`
-	ErrorDetail string `protobuf:"bytes,2,opt,name=errorDetail,proto3" json:"errorDetail,omitempty"`
-	// Types that are assignable to ErrorCommand:
-	//	*ServerMessage_CommandError_JoinRoom
-	//	*ServerMessage_CommandError_SendMessage
-	//	*ServerMessage_CommandError_LeaveRoom
-	ErrorCommand isServerMessage_CommandError_ErrorCommand `protobuf_oneof:"errorCommand"`
+	Team uint32 `protobuf:"varint,1,opt,name=team,proto3" json:"team,omitempty"`
 }
 
-func (x *ServerMessage_CommandError) Reset() {
-	*x = ServerMessage_CommandError{}
+func (x *ClientMessage_ChangeTeamCommand) Reset() {
+	*x = ClientMessage_ChangeTeamCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[8]
+		mi := &file_proto_room_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServerMessage_CommandError) String() string {
+func (x *ClientMessage_ChangeTeamCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerMessage_CommandError) ProtoMessage() {}
+func (*ClientMessage_ChangeTeamCommand) ProtoMessage() {}
 
-func (x *ServerMessage_CommandError) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[8]
+func (x *ClientMessage_ChangeTeamCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,100 +1208,104 @@ func (x *ServerMessage_CommandError) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerMessage_CommandError.ProtoReflect.Descriptor instead.
-func (*ServerMessage_CommandError) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{4, 0}
+// Deprecated: Use ClientMessage_ChangeTeamCommand.ProtoReflect.Descriptor instead.
+func (*ClientMessage_ChangeTeamCommand) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 3}
 }
 
-func (x *ServerMessage_CommandError) GetErrorCode() string {
+func (x *ClientMessage_ChangeTeamCommand) GetTeam() uint32 {
 	if x != nil {
-		return x.ErrorCode
+		return x.Team
 	}
-	return ""
+	return 0
 }
 
-func (x *ServerMessage_CommandError) GetErrorDetail() string {
-	if x != nil {
-		return x.ErrorDetail
-	}
-	return ""
-}
+// only the room master can swap teams
+type ClientMessage_SwapTeamsCommand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *ServerMessage_CommandError) GetErrorCommand() isServerMessage_CommandError_ErrorCommand {
-	if m != nil {
-		return m.ErrorCommand
-	}
-	return nil
+	ActorID1 string `protobuf:"bytes,1,opt,name=actorID1,proto3" json:"actorID1,omitempty"`
+	ActorID2 string `protobuf:"bytes,2,opt,name=actorID2,proto3" json:"actorID2,omitempty"`
 }
 
-func (x *ServerMessage_CommandError) GetJoinRoom() *ClientMessage_JoinRoomCommand {
-	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_JoinRoom); ok {
-		return x.JoinRoom
+func (x *ClientMessage_SwapTeamsCommand) Reset() {
+	*x = ClientMessage_SwapTeamsCommand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *ServerMessage_CommandError) GetSendMessage() *ClientMessage_SendMessageCommand {
-	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_SendMessage); ok {
-		return x.SendMessage
-	}
-	return nil
+func (x *ClientMessage_SwapTeamsCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ServerMessage_CommandError) GetLeaveRoom() *ClientMessage_LeaveRoomCommand {
-	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_LeaveRoom); ok {
-		return x.LeaveRoom
-	}
-	return nil
-}
+func (*ClientMessage_SwapTeamsCommand) ProtoMessage() {}
 
-type isServerMessage_CommandError_ErrorCommand interface {
-	isServerMessage_CommandError_ErrorCommand()
+func (x *ClientMessage_SwapTeamsCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type ServerMessage_CommandError_JoinRoom struct {
-	JoinRoom *ClientMessage_JoinRoomCommand `protobuf:"bytes,3,opt,name=joinRoom,proto3,oneof"`
+// Deprecated: Use ClientMessage_SwapTeamsCommand.ProtoReflect.Descriptor instead.
+func (*ClientMessage_SwapTeamsCommand) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 4}
 }
 
-type ServerMessage_CommandError_SendMessage struct {
-	SendMessage *ClientMessage_SendMessageCommand `protobuf:"bytes,4,opt,name=sendMessage,proto3,oneof"`
+func (x *ClientMessage_SwapTeamsCommand) GetActorID1() string {
+	if x != nil {
+		return x.ActorID1
+	}
+	return ""
 }
 
-type ServerMessage_CommandError_LeaveRoom struct {
-	LeaveRoom *ClientMessage_LeaveRoomCommand `protobuf:"bytes,5,opt,name=leaveRoom,proto3,oneof"`
+func (x *ClientMessage_SwapTeamsCommand) GetActorID2() string {
+	if x != nil {
+		return x.ActorID2
+	}
+	return ""
 }
 
-func (*ServerMessage_CommandError_JoinRoom) isServerMessage_CommandError_ErrorCommand() {}
-
-func (*ServerMessage_CommandError_SendMessage) isServerMessage_CommandError_ErrorCommand() {}
-
-func (*ServerMessage_CommandError_LeaveRoom) isServerMessage_CommandError_ErrorCommand() {}
-
-type ServerMessage_JoinRoomSuccess struct {
+// sends a request to the target actor, or to the room itself if
+// targetActorID is empty
+type ClientMessage_SendRequestCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ActorID string `protobuf:"bytes,1,opt,name=actorID,proto3" json:"actorID,omitempty"`
+	TargetActorID string   `protobuf:"bytes,1,opt,name=targetActorID,proto3" json:"targetActorID,omitempty"`
+	RequestID     uint64   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Message       *Message `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	TimeoutMillis uint32   `protobuf:"varint,4,opt,name=timeoutMillis,proto3" json:"timeoutMillis,omitempty"`
 }
 
-func (x *ServerMessage_JoinRoomSuccess) Reset() {
-	*x = ServerMessage_JoinRoomSuccess{}
+func (x *ClientMessage_SendRequestCommand) Reset() {
+	*x = ClientMessage_SendRequestCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[9]
+		mi := &file_proto_room_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServerMessage_JoinRoomSuccess) String() string {
+func (x *ClientMessage_SendRequestCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerMessage_JoinRoomSuccess) ProtoMessage() {}
+func (*ClientMessage_SendRequestCommand) ProtoMessage() {}
 
-func (x *ServerMessage_JoinRoomSuccess) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[9]
+func (x *ClientMessage_SendRequestCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -685,41 +1316,66 @@ func (x *ServerMessage_JoinRoomSuccess) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerMessage_JoinRoomSuccess.ProtoReflect.Descriptor instead.
-func (*ServerMessage_JoinRoomSuccess) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{4, 1}
+// Deprecated: Use ClientMessage_SendRequestCommand.ProtoReflect.Descriptor instead.
+func (*ClientMessage_SendRequestCommand) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 5}
 }
 
-func (x *ServerMessage_JoinRoomSuccess) GetActorID() string {
+func (x *ClientMessage_SendRequestCommand) GetTargetActorID() string {
 	if x != nil {
-		return x.ActorID
+		return x.TargetActorID
 	}
 	return ""
 }
 
-type ServerMessage_LeaveRoomSuccess struct {
+func (x *ClientMessage_SendRequestCommand) GetRequestID() uint64 {
+	if x != nil {
+		return x.RequestID
+	}
+	return 0
+}
+
+func (x *ClientMessage_SendRequestCommand) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ClientMessage_SendRequestCommand) GetTimeoutMillis() uint32 {
+	if x != nil {
+		return x.TimeoutMillis
+	}
+	return 0
+}
+
+type ClientMessage_SendResponseCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	RequesterActorID string   `protobuf:"bytes,1,opt,name=requesterActorID,proto3" json:"requesterActorID,omitempty"`
+	RequestID        uint64   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Message          *Message `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *ServerMessage_LeaveRoomSuccess) Reset() {
-	*x = ServerMessage_LeaveRoomSuccess{}
+func (x *ClientMessage_SendResponseCommand) Reset() {
+	*x = ClientMessage_SendResponseCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[10]
+		mi := &file_proto_room_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServerMessage_LeaveRoomSuccess) String() string {
+func (x *ClientMessage_SendResponseCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerMessage_LeaveRoomSuccess) ProtoMessage() {}
+func (*ClientMessage_SendResponseCommand) ProtoMessage() {}
 
-func (x *ServerMessage_LeaveRoomSuccess) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[10]
+func (x *ClientMessage_SendResponseCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -730,37 +1386,58 @@ func (x *ServerMessage_LeaveRoomSuccess) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerMessage_LeaveRoomSuccess.ProtoReflect.Descriptor instead.
-func (*ServerMessage_LeaveRoomSuccess) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{4, 2}
+// Deprecated: Use ClientMessage_SendResponseCommand.ProtoReflect.Descriptor instead.
+func (*ClientMessage_SendResponseCommand) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 6}
 }
 
-type ServerMessage_ReceivedMessageEvent struct {
+func (x *ClientMessage_SendResponseCommand) GetRequesterActorID() string {
+	if x != nil {
+		return x.RequesterActorID
+	}
+	return ""
+}
+
+func (x *ClientMessage_SendResponseCommand) GetRequestID() uint64 {
+	if x != nil {
+		return x.RequestID
+	}
+	return 0
+}
+
+func (x *ClientMessage_SendResponseCommand) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// the server replies with Pong
+type ClientMessage_PingCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message  *Message `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	SenderID string   `protobuf:"bytes,2,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	PingID uint64 `protobuf:"varint,1,opt,name=pingID,proto3" json:"pingID,omitempty"`
 }
 
-func (x *ServerMessage_ReceivedMessageEvent) Reset() {
-	*x = ServerMessage_ReceivedMessageEvent{}
+func (x *ClientMessage_PingCommand) Reset() {
+	*x = ClientMessage_PingCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[11]
+		mi := &file_proto_room_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServerMessage_ReceivedMessageEvent) String() string {
+func (x *ClientMessage_PingCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerMessage_ReceivedMessageEvent) ProtoMessage() {}
+func (*ClientMessage_PingCommand) ProtoMessage() {}
 
-func (x *ServerMessage_ReceivedMessageEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[11]
+func (x *ClientMessage_PingCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -771,51 +1448,44 @@ func (x *ServerMessage_ReceivedMessageEvent) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerMessage_ReceivedMessageEvent.ProtoReflect.Descriptor instead.
-func (*ServerMessage_ReceivedMessageEvent) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{4, 3}
-}
-
-func (x *ServerMessage_ReceivedMessageEvent) GetMessage() *Message {
-	if x != nil {
-		return x.Message
-	}
-	return nil
+// Deprecated: Use ClientMessage_PingCommand.ProtoReflect.Descriptor instead.
+func (*ClientMessage_PingCommand) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 7}
 }
 
-func (x *ServerMessage_ReceivedMessageEvent) GetSenderID() string {
+func (x *ClientMessage_PingCommand) GetPingID() uint64 {
 	if x != nil {
-		return x.SenderID
+		return x.PingID
 	}
-	return ""
+	return 0
 }
 
-type ServerMessage_JoinRoom struct {
+// reply to Ping from the server
+type ClientMessage_PongCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ActorIDList []string `protobuf:"bytes,1,rep,name=actorIDList,proto3" json:"actorIDList,omitempty"`
-	NewActorID  string   `protobuf:"bytes,2,opt,name=newActorID,proto3" json:"newActorID,omitempty"`
+	PingID uint64 `protobuf:"varint,1,opt,name=pingID,proto3" json:"pingID,omitempty"`
 }
 
-func (x *ServerMessage_JoinRoom) Reset() {
-	*x = ServerMessage_JoinRoom{}
+func (x *ClientMessage_PongCommand) Reset() {
+	*x = ClientMessage_PongCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[12]
+		mi := &file_proto_room_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServerMessage_JoinRoom) String() string {
+func (x *ClientMessage_PongCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerMessage_JoinRoom) ProtoMessage() {}
+func (*ClientMessage_PongCommand) ProtoMessage() {}
 
-func (x *ServerMessage_JoinRoom) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[12]
+func (x *ClientMessage_PongCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -826,51 +1496,950 @@ func (x *ServerMessage_JoinRoom) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerMessage_JoinRoom.ProtoReflect.Descriptor instead.
+// Deprecated: Use ClientMessage_PongCommand.ProtoReflect.Descriptor instead.
+func (*ClientMessage_PongCommand) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{3, 8}
+}
+
+func (x *ClientMessage_PongCommand) GetPingID() uint64 {
+	if x != nil {
+		return x.PingID
+	}
+	return 0
+}
+
+type ServerMessage_CommandError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// errorCode is the legacy string code, kept alongside code for older clients
+	ErrorCode     string                              `protobuf:"bytes,1,opt,name=errorCode,proto3" json:"errorCode,omitempty"`
+	Code          ServerMessage_CommandError_Code     `protobuf:"varint,10,opt,name=code,proto3,enum=quark.ServerMessage_CommandError_Code" json:"code,omitempty"`
+	ErrorDetail   string                              `protobuf:"bytes,2,opt,name=errorDetail,proto3" json:"errorDetail,omitempty"`
+	Details       *ServerMessage_CommandError_Details `protobuf:"bytes,11,opt,name=details,proto3" json:"details,omitempty"`
+	CorrelationID uint64                              `protobuf:"varint,12,opt,name=correlationID,proto3" json:"correlationID,omitempty"`
+	// Types that are assignable to ErrorCommand:
+	//	*ServerMessage_CommandError_JoinRoom
+	//	*ServerMessage_CommandError_SendMessage
+	//	*ServerMessage_CommandError_LeaveRoom
+	//	*ServerMessage_CommandError_ChangeTeam
+	//	*ServerMessage_CommandError_SwapTeams
+	//	*ServerMessage_CommandError_SendRequest
+	//	*ServerMessage_CommandError_SendResponse
+	ErrorCommand isServerMessage_CommandError_ErrorCommand `protobuf_oneof:"errorCommand"`
+}
+
+func (x *ServerMessage_CommandError) Reset() {
+	*x = ServerMessage_CommandError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_CommandError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_CommandError) ProtoMessage() {}
+
+func (x *ServerMessage_CommandError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_CommandError.ProtoReflect.Descriptor instead.
+func (*ServerMessage_CommandError) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *ServerMessage_CommandError) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ServerMessage_CommandError) GetCode() ServerMessage_CommandError_Code {
+	if x != nil {
+		return x.Code
+	}
+	return ServerMessage_CommandError_UNKNOWN
+}
+
+func (x *ServerMessage_CommandError) GetErrorDetail() string {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return ""
+}
+
+func (x *ServerMessage_CommandError) GetDetails() *ServerMessage_CommandError_Details {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *ServerMessage_CommandError) GetCorrelationID() uint64 {
+	if x != nil {
+		return x.CorrelationID
+	}
+	return 0
+}
+
+func (m *ServerMessage_CommandError) GetErrorCommand() isServerMessage_CommandError_ErrorCommand {
+	if m != nil {
+		return m.ErrorCommand
+	}
+	return nil
+}
+
+func (x *ServerMessage_CommandError) GetJoinRoom() *ClientMessage_JoinRoomCommand {
+	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_JoinRoom); ok {
+		return x.JoinRoom
+	}
+	return nil
+}
+
+func (x *ServerMessage_CommandError) GetSendMessage() *ClientMessage_SendMessageCommand {
+	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_SendMessage); ok {
+		return x.SendMessage
+	}
+	return nil
+}
+
+func (x *ServerMessage_CommandError) GetLeaveRoom() *ClientMessage_LeaveRoomCommand {
+	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_LeaveRoom); ok {
+		return x.LeaveRoom
+	}
+	return nil
+}
+
+func (x *ServerMessage_CommandError) GetChangeTeam() *ClientMessage_ChangeTeamCommand {
+	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_ChangeTeam); ok {
+		return x.ChangeTeam
+	}
+	return nil
+}
+
+func (x *ServerMessage_CommandError) GetSwapTeams() *ClientMessage_SwapTeamsCommand {
+	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_SwapTeams); ok {
+		return x.SwapTeams
+	}
+	return nil
+}
+
+func (x *ServerMessage_CommandError) GetSendRequest() *ClientMessage_SendRequestCommand {
+	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_SendRequest); ok {
+		return x.SendRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage_CommandError) GetSendResponse() *ClientMessage_SendResponseCommand {
+	if x, ok := x.GetErrorCommand().(*ServerMessage_CommandError_SendResponse); ok {
+		return x.SendResponse
+	}
+	return nil
+}
+
+type isServerMessage_CommandError_ErrorCommand interface {
+	isServerMessage_CommandError_ErrorCommand()
+}
+
+type ServerMessage_CommandError_JoinRoom struct {
+	JoinRoom *ClientMessage_JoinRoomCommand `protobuf:"bytes,3,opt,name=joinRoom,proto3,oneof"`
+}
+
+type ServerMessage_CommandError_SendMessage struct {
+	SendMessage *ClientMessage_SendMessageCommand `protobuf:"bytes,4,opt,name=sendMessage,proto3,oneof"`
+}
+
+type ServerMessage_CommandError_LeaveRoom struct {
+	LeaveRoom *ClientMessage_LeaveRoomCommand `protobuf:"bytes,5,opt,name=leaveRoom,proto3,oneof"`
+}
+
+type ServerMessage_CommandError_ChangeTeam struct {
+	ChangeTeam *ClientMessage_ChangeTeamCommand `protobuf:"bytes,6,opt,name=changeTeam,proto3,oneof"`
+}
+
+type ServerMessage_CommandError_SwapTeams struct {
+	SwapTeams *ClientMessage_SwapTeamsCommand `protobuf:"bytes,7,opt,name=swapTeams,proto3,oneof"`
+}
+
+type ServerMessage_CommandError_SendRequest struct {
+	SendRequest *ClientMessage_SendRequestCommand `protobuf:"bytes,8,opt,name=sendRequest,proto3,oneof"`
+}
+
+type ServerMessage_CommandError_SendResponse struct {
+	SendResponse *ClientMessage_SendResponseCommand `protobuf:"bytes,9,opt,name=sendResponse,proto3,oneof"`
+}
+
+func (*ServerMessage_CommandError_JoinRoom) isServerMessage_CommandError_ErrorCommand() {}
+
+func (*ServerMessage_CommandError_SendMessage) isServerMessage_CommandError_ErrorCommand() {}
+
+func (*ServerMessage_CommandError_LeaveRoom) isServerMessage_CommandError_ErrorCommand() {}
+
+func (*ServerMessage_CommandError_ChangeTeam) isServerMessage_CommandError_ErrorCommand() {}
+
+func (*ServerMessage_CommandError_SwapTeams) isServerMessage_CommandError_ErrorCommand() {}
+
+func (*ServerMessage_CommandError_SendRequest) isServerMessage_CommandError_ErrorCommand() {}
+
+func (*ServerMessage_CommandError_SendResponse) isServerMessage_CommandError_ErrorCommand() {}
+
+type ServerMessage_JoinRoomSuccess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorID string `protobuf:"bytes,1,opt,name=actorID,proto3" json:"actorID,omitempty"`
+}
+
+func (x *ServerMessage_JoinRoomSuccess) Reset() {
+	*x = ServerMessage_JoinRoomSuccess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_JoinRoomSuccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_JoinRoomSuccess) ProtoMessage() {}
+
+func (x *ServerMessage_JoinRoomSuccess) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_JoinRoomSuccess.ProtoReflect.Descriptor instead.
+func (*ServerMessage_JoinRoomSuccess) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *ServerMessage_JoinRoomSuccess) GetActorID() string {
+	if x != nil {
+		return x.ActorID
+	}
+	return ""
+}
+
+type ServerMessage_LeaveRoomSuccess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ServerMessage_LeaveRoomSuccess) Reset() {
+	*x = ServerMessage_LeaveRoomSuccess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_LeaveRoomSuccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_LeaveRoomSuccess) ProtoMessage() {}
+
+func (x *ServerMessage_LeaveRoomSuccess) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_LeaveRoomSuccess.ProtoReflect.Descriptor instead.
+func (*ServerMessage_LeaveRoomSuccess) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 2}
+}
+
+// the message passed the schemas and interceptors of the room
+type ServerMessage_MessageAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ServerMessage_MessageAck) Reset() {
+	*x = ServerMessage_MessageAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_MessageAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_MessageAck) ProtoMessage() {}
+
+func (x *ServerMessage_MessageAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_MessageAck.ProtoReflect.Descriptor instead.
+func (*ServerMessage_MessageAck) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 3}
+}
+
+type ServerMessage_ReceivedMessageEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message  *Message `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	SenderID string   `protobuf:"bytes,2,opt,name=senderID,proto3" json:"senderID,omitempty"`
+}
+
+func (x *ServerMessage_ReceivedMessageEvent) Reset() {
+	*x = ServerMessage_ReceivedMessageEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_ReceivedMessageEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_ReceivedMessageEvent) ProtoMessage() {}
+
+func (x *ServerMessage_ReceivedMessageEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_ReceivedMessageEvent.ProtoReflect.Descriptor instead.
+func (*ServerMessage_ReceivedMessageEvent) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 4}
+}
+
+func (x *ServerMessage_ReceivedMessageEvent) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ServerMessage_ReceivedMessageEvent) GetSenderID() string {
+	if x != nil {
+		return x.SenderID
+	}
+	return ""
+}
+
+type ServerMessage_ReceivedRequestEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message   *Message `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	SenderID  string   `protobuf:"bytes,2,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	RequestID uint64   `protobuf:"varint,3,opt,name=requestID,proto3" json:"requestID,omitempty"`
+}
+
+func (x *ServerMessage_ReceivedRequestEvent) Reset() {
+	*x = ServerMessage_ReceivedRequestEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_ReceivedRequestEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_ReceivedRequestEvent) ProtoMessage() {}
+
+func (x *ServerMessage_ReceivedRequestEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_ReceivedRequestEvent.ProtoReflect.Descriptor instead.
+func (*ServerMessage_ReceivedRequestEvent) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 5}
+}
+
+func (x *ServerMessage_ReceivedRequestEvent) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ServerMessage_ReceivedRequestEvent) GetSenderID() string {
+	if x != nil {
+		return x.SenderID
+	}
+	return ""
+}
+
+func (x *ServerMessage_ReceivedRequestEvent) GetRequestID() uint64 {
+	if x != nil {
+		return x.RequestID
+	}
+	return 0
+}
+
+type ServerMessage_ReceivedResponseEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message     *Message `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ResponderID string   `protobuf:"bytes,2,opt,name=responderID,proto3" json:"responderID,omitempty"`
+	RequestID   uint64   `protobuf:"varint,3,opt,name=requestID,proto3" json:"requestID,omitempty"`
+}
+
+func (x *ServerMessage_ReceivedResponseEvent) Reset() {
+	*x = ServerMessage_ReceivedResponseEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_ReceivedResponseEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_ReceivedResponseEvent) ProtoMessage() {}
+
+func (x *ServerMessage_ReceivedResponseEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_ReceivedResponseEvent.ProtoReflect.Descriptor instead.
+func (*ServerMessage_ReceivedResponseEvent) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 6}
+}
+
+func (x *ServerMessage_ReceivedResponseEvent) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ServerMessage_ReceivedResponseEvent) GetResponderID() string {
+	if x != nil {
+		return x.ResponderID
+	}
+	return ""
+}
+
+func (x *ServerMessage_ReceivedResponseEvent) GetRequestID() uint64 {
+	if x != nil {
+		return x.RequestID
+	}
+	return 0
+}
+
+type ServerMessage_JoinRoom struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorIDList []string          `protobuf:"bytes,1,rep,name=actorIDList,proto3" json:"actorIDList,omitempty"`
+	NewActorID  string            `protobuf:"bytes,2,opt,name=newActorID,proto3" json:"newActorID,omitempty"`
+	Teams       map[string]uint32 `protobuf:"bytes,3,rep,name=teams,proto3" json:"teams,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	BotIDList   []string          `protobuf:"bytes,4,rep,name=botIDList,proto3" json:"botIDList,omitempty"`
+}
+
+func (x *ServerMessage_JoinRoom) Reset() {
+	*x = ServerMessage_JoinRoom{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_JoinRoom) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_JoinRoom) ProtoMessage() {}
+
+func (x *ServerMessage_JoinRoom) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_JoinRoom.ProtoReflect.Descriptor instead.
 func (*ServerMessage_JoinRoom) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{4, 4}
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 7}
+}
+
+func (x *ServerMessage_JoinRoom) GetActorIDList() []string {
+	if x != nil {
+		return x.ActorIDList
+	}
+	return nil
+}
+
+func (x *ServerMessage_JoinRoom) GetNewActorID() string {
+	if x != nil {
+		return x.NewActorID
+	}
+	return ""
+}
+
+func (x *ServerMessage_JoinRoom) GetTeams() map[string]uint32 {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+func (x *ServerMessage_JoinRoom) GetBotIDList() []string {
+	if x != nil {
+		return x.BotIDList
+	}
+	return nil
+}
+
+type ServerMessage_LeaveRoom struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorIDList    []string                       `protobuf:"bytes,1,rep,name=actorIDList,proto3" json:"actorIDList,omitempty"`
+	RemovedActorID string                         `protobuf:"bytes,2,opt,name=removedActorID,proto3" json:"removedActorID,omitempty"`
+	Teams          map[string]uint32              `protobuf:"bytes,3,rep,name=teams,proto3" json:"teams,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	BotIDList      []string                       `protobuf:"bytes,4,rep,name=botIDList,proto3" json:"botIDList,omitempty"`
+	Reason         ServerMessage_LeaveRoom_Reason `protobuf:"varint,5,opt,name=reason,proto3,enum=quark.ServerMessage_LeaveRoom_Reason" json:"reason,omitempty"`
+	ReasonDetail   string                         `protobuf:"bytes,6,opt,name=reasonDetail,proto3" json:"reasonDetail,omitempty"`
+}
+
+func (x *ServerMessage_LeaveRoom) Reset() {
+	*x = ServerMessage_LeaveRoom{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_LeaveRoom) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_LeaveRoom) ProtoMessage() {}
+
+func (x *ServerMessage_LeaveRoom) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_LeaveRoom.ProtoReflect.Descriptor instead.
+func (*ServerMessage_LeaveRoom) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 8}
+}
+
+func (x *ServerMessage_LeaveRoom) GetActorIDList() []string {
+	if x != nil {
+		return x.ActorIDList
+	}
+	return nil
+}
+
+func (x *ServerMessage_LeaveRoom) GetRemovedActorID() string {
+	if x != nil {
+		return x.RemovedActorID
+	}
+	return ""
+}
+
+func (x *ServerMessage_LeaveRoom) GetTeams() map[string]uint32 {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+func (x *ServerMessage_LeaveRoom) GetBotIDList() []string {
+	if x != nil {
+		return x.BotIDList
+	}
+	return nil
+}
+
+func (x *ServerMessage_LeaveRoom) GetReason() ServerMessage_LeaveRoom_Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return ServerMessage_LeaveRoom_VOLUNTARY
+}
+
+func (x *ServerMessage_LeaveRoom) GetReasonDetail() string {
+	if x != nil {
+		return x.ReasonDetail
+	}
+	return ""
+}
+
+// the last event of the room; the client is no longer in the room
+type ServerMessage_RoomClosed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reason ServerMessage_LeaveRoom_Reason `protobuf:"varint,1,opt,name=reason,proto3,enum=quark.ServerMessage_LeaveRoom_Reason" json:"reason,omitempty"`
+	Detail string                         `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *ServerMessage_RoomClosed) Reset() {
+	*x = ServerMessage_RoomClosed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_RoomClosed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_RoomClosed) ProtoMessage() {}
+
+func (x *ServerMessage_RoomClosed) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_RoomClosed.ProtoReflect.Descriptor instead.
+func (*ServerMessage_RoomClosed) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 9}
+}
+
+func (x *ServerMessage_RoomClosed) GetReason() ServerMessage_LeaveRoom_Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return ServerMessage_LeaveRoom_VOLUNTARY
+}
+
+func (x *ServerMessage_RoomClosed) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// the client must reply with PongCommand
+type ServerMessage_Ping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PingID uint64 `protobuf:"varint,1,opt,name=pingID,proto3" json:"pingID,omitempty"`
+}
+
+func (x *ServerMessage_Ping) Reset() {
+	*x = ServerMessage_Ping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_Ping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_Ping) ProtoMessage() {}
+
+func (x *ServerMessage_Ping) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_Ping.ProtoReflect.Descriptor instead.
+func (*ServerMessage_Ping) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 10}
+}
+
+func (x *ServerMessage_Ping) GetPingID() uint64 {
+	if x != nil {
+		return x.PingID
+	}
+	return 0
+}
+
+type ServerMessage_Pong struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PingID uint64 `protobuf:"varint,1,opt,name=pingID,proto3" json:"pingID,omitempty"`
+}
+
+func (x *ServerMessage_Pong) Reset() {
+	*x = ServerMessage_Pong{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_Pong) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_Pong) ProtoMessage() {}
+
+func (x *ServerMessage_Pong) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_Pong.ProtoReflect.Descriptor instead.
+func (*ServerMessage_Pong) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 11}
+}
+
+func (x *ServerMessage_Pong) GetPingID() uint64 {
+	if x != nil {
+		return x.PingID
+	}
+	return 0
+}
+
+type ServerMessage_ActorPropertiesChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorID    string            `protobuf:"bytes,1,opt,name=actorID,proto3" json:"actorID,omitempty"`
+	Properties map[string]string `protobuf:"bytes,2,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServerMessage_ActorPropertiesChanged) Reset() {
+	*x = ServerMessage_ActorPropertiesChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_ActorPropertiesChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_ActorPropertiesChanged) ProtoMessage() {}
+
+func (x *ServerMessage_ActorPropertiesChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_ActorPropertiesChanged.ProtoReflect.Descriptor instead.
+func (*ServerMessage_ActorPropertiesChanged) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 12}
+}
+
+func (x *ServerMessage_ActorPropertiesChanged) GetActorID() string {
+	if x != nil {
+		return x.ActorID
+	}
+	return ""
+}
+
+func (x *ServerMessage_ActorPropertiesChanged) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type ServerMessage_TeamChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Teams map[string]uint32 `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *ServerMessage_TeamChanged) Reset() {
+	*x = ServerMessage_TeamChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_TeamChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_TeamChanged) ProtoMessage() {}
+
+func (x *ServerMessage_TeamChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *ServerMessage_JoinRoom) GetActorIDList() []string {
-	if x != nil {
-		return x.ActorIDList
-	}
-	return nil
+// Deprecated: Use ServerMessage_TeamChanged.ProtoReflect.Descriptor instead.
+func (*ServerMessage_TeamChanged) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 13}
 }
 
-func (x *ServerMessage_JoinRoom) GetNewActorID() string {
+func (x *ServerMessage_TeamChanged) GetTeams() map[string]uint32 {
 	if x != nil {
-		return x.NewActorID
+		return x.Teams
 	}
-	return ""
+	return nil
 }
 
-type ServerMessage_LeaveRoom struct {
+type ServerMessage_Batch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ActorIDList    []string `protobuf:"bytes,1,rep,name=actorIDList,proto3" json:"actorIDList,omitempty"`
-	RemovedActorID string   `protobuf:"bytes,2,opt,name=removedActorID,proto3" json:"removedActorID,omitempty"`
+	Messages []*ServerMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
 }
 
-func (x *ServerMessage_LeaveRoom) Reset() {
-	*x = ServerMessage_LeaveRoom{}
+func (x *ServerMessage_Batch) Reset() {
+	*x = ServerMessage_Batch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_room_proto_msgTypes[13]
+		mi := &file_proto_room_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServerMessage_LeaveRoom) String() string {
+func (x *ServerMessage_Batch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerMessage_LeaveRoom) ProtoMessage() {}
+func (*ServerMessage_Batch) ProtoMessage() {}
 
-func (x *ServerMessage_LeaveRoom) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_room_proto_msgTypes[13]
+func (x *ServerMessage_Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -881,82 +2450,243 @@ func (x *ServerMessage_LeaveRoom) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerMessage_LeaveRoom.ProtoReflect.Descriptor instead.
-func (*ServerMessage_LeaveRoom) Descriptor() ([]byte, []int) {
-	return file_proto_room_proto_rawDescGZIP(), []int{4, 5}
+// Deprecated: Use ServerMessage_Batch.ProtoReflect.Descriptor instead.
+func (*ServerMessage_Batch) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 14}
 }
 
-func (x *ServerMessage_LeaveRoom) GetActorIDList() []string {
+func (x *ServerMessage_Batch) GetMessages() []*ServerMessage {
 	if x != nil {
-		return x.ActorIDList
+		return x.Messages
 	}
 	return nil
 }
 
-func (x *ServerMessage_LeaveRoom) GetRemovedActorID() string {
+type ServerMessage_CommandError_Details struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// e.g. the message code and the reason of INVALID_PAYLOAD
+	Metadata map[string]string `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServerMessage_CommandError_Details) Reset() {
+	*x = ServerMessage_CommandError_Details{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage_CommandError_Details) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage_CommandError_Details) ProtoMessage() {}
+
+func (x *ServerMessage_CommandError_Details) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage_CommandError_Details.ProtoReflect.Descriptor instead.
+func (*ServerMessage_CommandError_Details) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5, 0, 0}
+}
+
+func (x *ServerMessage_CommandError_Details) GetMetadata() map[string]string {
 	if x != nil {
-		return x.RemovedActorID
+		return x.Metadata
 	}
-	return ""
+	return nil
 }
 
 var File_proto_room_proto protoreflect.FileDescriptor
 
 var file_proto_room_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x6f, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x05, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x22, 0x2f, 0x0a, 0x11, 0x43, 0x72, 0x65,
+	0x74, 0x6f, 0x12, 0x05, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x22, 0x65, 0x0a, 0x11, 0x43, 0x72, 0x65,
 	0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
 	0x0a, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x50, 0x0a, 0x12, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x6c, 0x72, 0x65,
-	0x61, 0x64, 0x79, 0x45, 0x78, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
-	0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x45, 0x78, 0x69, 0x73, 0x74, 0x22, 0xf1, 0x02, 0x0a,
-	0x0d, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x42,
-	0x0a, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x24, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x43,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x6f,
-	0x6f, 0x6d, 0x12, 0x4b, 0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65,
-	0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x48, 0x00, 0x52, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x45, 0x0a, 0x09, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f,
-	0x6f, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x65, 0x61,
-	0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x1a, 0x29, 0x0a, 0x0f, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f,
-	0x6f, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f,
-	0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49,
-	0x44, 0x1a, 0x3e, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x28, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
-	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x1a, 0x12, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f,
-	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x22, 0x37, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63,
-	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xf3, 0x08, 0x0a, 0x0d, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x6f,
-	0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x0f, 0x6f, 0x6e, 0x43, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x54, 0x0a, 0x11, 0x6f, 0x6e,
-	0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e,
-	0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x11, 0x6f,
-	0x6e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x12, 0x57, 0x0a, 0x12, 0x6f, 0x6e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53,
-	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x71,
-	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x12, 0x6f, 0x6e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f,
-	0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x59, 0x0a, 0x11, 0x6f, 0x6e, 0x4d,
+	0x52, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x0b, 0x72, 0x6f,
+	0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x72, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0xd1, 0x04, 0x0a, 0x0b, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x74, 0x65, 0x61, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x74, 0x65, 0x61, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x6f,
+	0x6f, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x6f,
+	0x6f, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x6f, 0x62, 0x62,
+	0x79, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0f, 0x6c, 0x6f, 0x62, 0x62, 0x79, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x61, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x62, 0x62, 0x79, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x62, 0x62, 0x79, 0x12, 0x28, 0x0a, 0x0f,
+	0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x18,
+	0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x12, 0x3f, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x65,
+	0x61, 0x6d, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x71, 0x75, 0x61, 0x72,
+	0x6b, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x75, 0x73,
+	0x65, 0x72, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70, 0x65,
+	0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x54, 0x65,
+	0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x50, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f,
+	0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f,
+	0x6f, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d,
+	0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x45, 0x78, 0x69,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64,
+	0x79, 0x45, 0x78, 0x69, 0x73, 0x74, 0x22, 0xe2, 0x0b, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d,
+	0x49, 0x44, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44,
+	0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x42, 0x0a, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4a,
+	0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00,
+	0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x4b, 0x0a, 0x0b, 0x73, 0x65,
+	0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x65, 0x6e, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x45, 0x0a, 0x09, 0x6c, 0x65, 0x61, 0x76, 0x65,
+	0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x71, 0x75, 0x61,
+	0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x48,
+	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x65, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54,
+	0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x54, 0x65, 0x61, 0x6d, 0x12, 0x45, 0x0a, 0x09, 0x73, 0x77, 0x61, 0x70,
+	0x54, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x71, 0x75,
+	0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x48, 0x00, 0x52, 0x09, 0x73, 0x77, 0x61, 0x70, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x12,
+	0x4b, 0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52,
+	0x0b, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x0c,
+	0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0c,
+	0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x04,
+	0x70, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x71, 0x75, 0x61,
+	0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x50, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x04,
+	0x70, 0x69, 0x6e, 0x67, 0x12, 0x36, 0x0a, 0x04, 0x70, 0x6f, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x50, 0x6f, 0x6e, 0x67, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x04, 0x70, 0x6f, 0x6e, 0x67, 0x1a, 0x29, 0x0a, 0x0f,
+	0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x1a, 0xd8, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x28,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x46, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53,
+	0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x65, 0x4b,
+	0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x03, 0x61, 0x63, 0x6b, 0x22, 0x1c, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x08,
+	0x0a, 0x04, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x45, 0x41, 0x4d,
+	0x10, 0x01, 0x1a, 0x12, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x1a, 0x27, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x54, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x1a,
+	0x4a, 0x0a, 0x10, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x31, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x31, 0x12,
+	0x1a, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x32, 0x1a, 0xa8, 0x01, 0x0a, 0x12,
+	0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x41, 0x63, 0x74, 0x6f,
+	0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12, 0x28, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x24, 0x0a, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x1a, 0x89, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x2a,
+	0x0a, 0x10, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x41, 0x63, 0x74, 0x6f, 0x72,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x65, 0x72, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12, 0x28, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x71, 0x75, 0x61, 0x72,
+	0x6b, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x1a, 0x25, 0x0a, 0x0b, 0x50, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x70, 0x69, 0x6e, 0x67, 0x49, 0x44, 0x1a, 0x25, 0x0a, 0x0b, 0x50, 0x6f, 0x6e,
+	0x67, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e, 0x67,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70, 0x69, 0x6e, 0x67, 0x49, 0x44,
+	0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0x37, 0x0a, 0x07, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x22, 0xfe, 0x20, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x24,
+	0x0a, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x12, 0x4d, 0x0a, 0x0f, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x48, 0x00, 0x52, 0x0f, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x46, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x12, 0x54, 0x0a, 0x11, 0x6f, 0x6e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f,
+	0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x11, 0x6f, 0x6e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x57, 0x0a, 0x12, 0x6f, 0x6e, 0x4c,
+	0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x65, 0x61, 0x76,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x12,
+	0x6f, 0x6e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x45, 0x0a, 0x0c, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x41,
+	0x63, 0x6b, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0c, 0x6f, 0x6e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x59, 0x0a, 0x11, 0x6f, 0x6e, 0x4d,
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x04,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72,
 	0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69,
@@ -970,57 +2700,242 @@ var file_proto_room_proto_rawDesc = []byte{
 	0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x71, 0x75, 0x61,
 	0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
 	0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x48, 0x00, 0x52, 0x0b, 0x6f, 0x6e,
-	0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x1a, 0xb6, 0x02, 0x0a, 0x0c, 0x43, 0x6f,
-	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x42, 0x0a, 0x08, 0x6a, 0x6f,
-	0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x71,
+	0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x48, 0x0a, 0x0d, 0x6f, 0x6e, 0x54,
+	0x65, 0x61, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x48, 0x00, 0x52, 0x0d, 0x6f, 0x6e, 0x54, 0x65, 0x61, 0x6d, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x12, 0x59, 0x0a, 0x11, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x11, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x5c,
+	0x0a, 0x12, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x71, 0x75, 0x61,
+	0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x12, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x06,
+	0x6f, 0x6e, 0x50, 0x69, 0x6e, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x06, 0x6f, 0x6e, 0x50, 0x69, 0x6e,
+	0x67, 0x12, 0x33, 0x0a, 0x06, 0x6f, 0x6e, 0x50, 0x6f, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x50, 0x6f, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x06,
+	0x6f, 0x6e, 0x50, 0x6f, 0x6e, 0x67, 0x12, 0x69, 0x0a, 0x18, 0x6f, 0x6e, 0x41, 0x63, 0x74, 0x6f,
+	0x72, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x41,
+	0x63, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x18, 0x6f, 0x6e, 0x41, 0x63, 0x74, 0x6f, 0x72,
+	0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x12, 0x45, 0x0a, 0x0c, 0x6f, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x6f, 0x6e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x07, 0x6f, 0x6e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x71, 0x75, 0x61, 0x72,
+	0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x1a, 0x97, 0x0a, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x3a, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
+	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x43, 0x0a,
+	0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x42, 0x0a, 0x08, 0x6a, 0x6f, 0x69, 0x6e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x71, 0x75, 0x61,
+	0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x48, 0x00, 0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x4b, 0x0a, 0x0b,
+	0x73, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x65,
+	0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x45, 0x0a, 0x09, 0x6c, 0x65, 0x61,
+	0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x71,
 	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x48, 0x00, 0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x4b,
-	0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0b,
-	0x73, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x45, 0x0a, 0x09, 0x6c,
-	0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
-	0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f,
-	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f,
-	0x6f, 0x6d, 0x42, 0x0e, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x1a, 0x2b, 0x0a, 0x0f, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x1a,
-	0x12, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x1a, 0x5c, 0x0a, 0x14, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x71,
-	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49,
-	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49,
-	0x44, 0x1a, 0x4c, 0x0a, 0x08, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x20, 0x0a,
-	0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x4c, 0x69, 0x73, 0x74, 0x12,
-	0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x1a,
-	0x55, 0x0a, 0x09, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x20, 0x0a, 0x0b,
+	0x67, 0x65, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d,
+	0x12, 0x48, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x65, 0x61, 0x6d, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x54, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0a,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x65, 0x61, 0x6d, 0x12, 0x45, 0x0a, 0x09, 0x73, 0x77,
+	0x61, 0x70, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x09, 0x73, 0x77, 0x61, 0x70, 0x54, 0x65, 0x61, 0x6d,
+	0x73, 0x12, 0x4b, 0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x6e,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48,
+	0x00, 0x52, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e,
+	0x0a, 0x0c, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00,
+	0x52, 0x0c, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x9b,
+	0x01, 0x0a, 0x07, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x53, 0x0a, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x2e,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a,
+	0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xeb, 0x02, 0x0a,
+	0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46,
+	0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x46,
+	0x55, 0x4c, 0x4c, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x43, 0x4c,
+	0x4f, 0x53, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x4e, 0x4f, 0x54, 0x5f, 0x4d, 0x45,
+	0x4d, 0x42, 0x45, 0x52, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44,
+	0x59, 0x5f, 0x4d, 0x45, 0x4d, 0x42, 0x45, 0x52, 0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x4f,
+	0x52, 0x42, 0x49, 0x44, 0x44, 0x45, 0x4e, 0x10, 0x08, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x56,
+	0x41, 0x4c, 0x49, 0x44, 0x5f, 0x50, 0x41, 0x59, 0x4c, 0x4f, 0x41, 0x44, 0x10, 0x09, 0x12, 0x0c,
+	0x0a, 0x08, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x0a, 0x12, 0x12, 0x0a, 0x0e,
+	0x54, 0x45, 0x41, 0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x0b,
+	0x12, 0x0d, 0x0a, 0x09, 0x54, 0x45, 0x41, 0x4d, 0x5f, 0x46, 0x55, 0x4c, 0x4c, 0x10, 0x0c, 0x12,
+	0x13, 0x0a, 0x0f, 0x4e, 0x4f, 0x54, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x4d, 0x41, 0x53, 0x54,
+	0x45, 0x52, 0x10, 0x0d, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f,
+	0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x0e, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x51,
+	0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f,
+	0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x0f, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x51, 0x55, 0x45,
+	0x53, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x4c, 0x45, 0x46, 0x54, 0x10, 0x10,
+	0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x44, 0x55, 0x50, 0x4c,
+	0x49, 0x43, 0x41, 0x54, 0x45, 0x44, 0x10, 0x11, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x51, 0x55,
+	0x45, 0x53, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x48, 0x41, 0x4e, 0x44, 0x4c, 0x45, 0x44, 0x10,
+	0x12, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x4e, 0x4f, 0x54,
+	0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x13, 0x42, 0x0e, 0x0a, 0x0c, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x1a, 0x2b, 0x0a, 0x0f, 0x4a, 0x6f,
+	0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x1a, 0x12, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x76, 0x65,
+	0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x1a, 0x0c, 0x0a, 0x0a, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x41, 0x63, 0x6b, 0x1a, 0x5c, 0x0a, 0x14, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x28, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x7a, 0x0a, 0x14, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x28, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x44, 0x1a, 0x81, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x1a, 0xe4, 0x01, 0x0a, 0x08, 0x4a, 0x6f, 0x69, 0x6e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x4c,
+	0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x49, 0x44, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x41, 0x63, 0x74,
+	0x6f, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x41,
+	0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x3e, 0x0a, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6f, 0x74, 0x49, 0x44, 0x4c,
+	0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6f, 0x74, 0x49, 0x44,
+	0x4c, 0x69, 0x73, 0x74, 0x1a, 0x38, 0x0a, 0x0a, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xbd,
+	0x03, 0x0a, 0x09, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x20, 0x0a, 0x0b,
 	0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
 	0x09, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x26,
 	0x0a, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x41,
-	0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x32,
-	0x84, 0x01, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x41, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x18, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x19, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
-	0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x14, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x14, 0x2e, 0x71,
+	0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x3f, 0x0a, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x65, 0x61, 0x76,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6f, 0x74, 0x49, 0x44,
+	0x4c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6f, 0x74, 0x49,
+	0x44, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x65, 0x61, 0x76,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x1a, 0x38, 0x0a, 0x0a, 0x54, 0x65, 0x61, 0x6d,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x6a, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0d, 0x0a, 0x09,
+	0x56, 0x4f, 0x4c, 0x55, 0x4e, 0x54, 0x41, 0x52, 0x59, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x44,
+	0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0d, 0x0a,
+	0x09, 0x54, 0x49, 0x4d, 0x45, 0x44, 0x5f, 0x4f, 0x55, 0x54, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06,
+	0x4b, 0x49, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f, 0x4f, 0x4d,
+	0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x52,
+	0x56, 0x45, 0x52, 0x5f, 0x53, 0x48, 0x55, 0x54, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x05, 0x1a, 0x63,
+	0x0a, 0x0a, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x2e, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x1a, 0x1e, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x69, 0x6e, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70, 0x69, 0x6e,
+	0x67, 0x49, 0x44, 0x1a, 0x1e, 0x0a, 0x04, 0x50, 0x6f, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x69, 0x6e, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70, 0x69, 0x6e,
+	0x67, 0x49, 0x44, 0x1a, 0xce, 0x01, 0x0a, 0x16, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x5b, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70,
+	0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x71,
 	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x0d, 0x5a, 0x0b, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x67, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69,
+	0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72,
+	0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65,
+	0x72, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+	0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x8a, 0x01, 0x0a, 0x0b, 0x54, 0x65, 0x61, 0x6d, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x12, 0x41, 0x0a, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x54, 0x65, 0x61, 0x6d, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x39, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x42, 0x07, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x32, 0x84, 0x01, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x41,
+	0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x18, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x39, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x14, 0x2e, 0x71,
+	0x75, 0x61, 0x72, 0x6b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x1a, 0x14, 0x2e, 0x71, 0x75, 0x61, 0x72, 0x6b, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x0d, 0x5a, 0x0b,
+	0x71, 0x75, 0x61, 0x72, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1035,47 +2950,112 @@ func file_proto_room_proto_rawDescGZIP() []byte {
 	return file_proto_room_proto_rawDescData
 }
 
-var file_proto_room_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_room_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_room_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_proto_room_proto_goTypes = []interface{}{
-	(*CreateRoomRequest)(nil),                  // 0: quark.CreateRoomRequest
-	(*CreateRoomResponse)(nil),                 // 1: quark.CreateRoomResponse
-	(*ClientMessage)(nil),                      // 2: quark.ClientMessage
-	(*Message)(nil),                            // 3: quark.Message
-	(*ServerMessage)(nil),                      // 4: quark.ServerMessage
-	(*ClientMessage_JoinRoomCommand)(nil),      // 5: quark.ClientMessage.JoinRoomCommand
-	(*ClientMessage_SendMessageCommand)(nil),   // 6: quark.ClientMessage.SendMessageCommand
-	(*ClientMessage_LeaveRoomCommand)(nil),     // 7: quark.ClientMessage.LeaveRoomCommand
-	(*ServerMessage_CommandError)(nil),         // 8: quark.ServerMessage.CommandError
-	(*ServerMessage_JoinRoomSuccess)(nil),      // 9: quark.ServerMessage.JoinRoomSuccess
-	(*ServerMessage_LeaveRoomSuccess)(nil),     // 10: quark.ServerMessage.LeaveRoomSuccess
-	(*ServerMessage_ReceivedMessageEvent)(nil), // 11: quark.ServerMessage.ReceivedMessageEvent
-	(*ServerMessage_JoinRoom)(nil),             // 12: quark.ServerMessage.JoinRoom
-	(*ServerMessage_LeaveRoom)(nil),            // 13: quark.ServerMessage.LeaveRoom
+	(ClientMessage_SendMessageCommand_Target)(0), // 0: quark.ClientMessage.SendMessageCommand.Target
+	(ServerMessage_CommandError_Code)(0),         // 1: quark.ServerMessage.CommandError.Code
+	(ServerMessage_LeaveRoom_Reason)(0),          // 2: quark.ServerMessage.LeaveRoom.Reason
+	(*CreateRoomRequest)(nil),                    // 3: quark.CreateRoomRequest
+	(*RoomOptions)(nil),                          // 4: quark.RoomOptions
+	(*CreateRoomResponse)(nil),                   // 5: quark.CreateRoomResponse
+	(*ClientMessage)(nil),                        // 6: quark.ClientMessage
+	(*Message)(nil),                              // 7: quark.Message
+	(*ServerMessage)(nil),                        // 8: quark.ServerMessage
+	nil,                                          // 9: quark.RoomOptions.PropertiesEntry
+	nil,                                          // 10: quark.RoomOptions.UserTeamsEntry
+	(*ClientMessage_JoinRoomCommand)(nil),        // 11: quark.ClientMessage.JoinRoomCommand
+	(*ClientMessage_SendMessageCommand)(nil),     // 12: quark.ClientMessage.SendMessageCommand
+	(*ClientMessage_LeaveRoomCommand)(nil),       // 13: quark.ClientMessage.LeaveRoomCommand
+	(*ClientMessage_ChangeTeamCommand)(nil),      // 14: quark.ClientMessage.ChangeTeamCommand
+	(*ClientMessage_SwapTeamsCommand)(nil),       // 15: quark.ClientMessage.SwapTeamsCommand
+	(*ClientMessage_SendRequestCommand)(nil),     // 16: quark.ClientMessage.SendRequestCommand
+	(*ClientMessage_SendResponseCommand)(nil),    // 17: quark.ClientMessage.SendResponseCommand
+	(*ClientMessage_PingCommand)(nil),            // 18: quark.ClientMessage.PingCommand
+	(*ClientMessage_PongCommand)(nil),            // 19: quark.ClientMessage.PongCommand
+	(*ServerMessage_CommandError)(nil),           // 20: quark.ServerMessage.CommandError
+	(*ServerMessage_JoinRoomSuccess)(nil),        // 21: quark.ServerMessage.JoinRoomSuccess
+	(*ServerMessage_LeaveRoomSuccess)(nil),       // 22: quark.ServerMessage.LeaveRoomSuccess
+	(*ServerMessage_MessageAck)(nil),             // 23: quark.ServerMessage.MessageAck
+	(*ServerMessage_ReceivedMessageEvent)(nil),   // 24: quark.ServerMessage.ReceivedMessageEvent
+	(*ServerMessage_ReceivedRequestEvent)(nil),   // 25: quark.ServerMessage.ReceivedRequestEvent
+	(*ServerMessage_ReceivedResponseEvent)(nil),  // 26: quark.ServerMessage.ReceivedResponseEvent
+	(*ServerMessage_JoinRoom)(nil),               // 27: quark.ServerMessage.JoinRoom
+	(*ServerMessage_LeaveRoom)(nil),              // 28: quark.ServerMessage.LeaveRoom
+	(*ServerMessage_RoomClosed)(nil),             // 29: quark.ServerMessage.RoomClosed
+	(*ServerMessage_Ping)(nil),                   // 30: quark.ServerMessage.Ping
+	(*ServerMessage_Pong)(nil),                   // 31: quark.ServerMessage.Pong
+	(*ServerMessage_ActorPropertiesChanged)(nil), // 32: quark.ServerMessage.ActorPropertiesChanged
+	(*ServerMessage_TeamChanged)(nil),            // 33: quark.ServerMessage.TeamChanged
+	(*ServerMessage_Batch)(nil),                  // 34: quark.ServerMessage.Batch
+	(*ServerMessage_CommandError_Details)(nil),   // 35: quark.ServerMessage.CommandError.Details
+	nil, // 36: quark.ServerMessage.CommandError.Details.MetadataEntry
+	nil, // 37: quark.ServerMessage.JoinRoom.TeamsEntry
+	nil, // 38: quark.ServerMessage.LeaveRoom.TeamsEntry
+	nil, // 39: quark.ServerMessage.ActorPropertiesChanged.PropertiesEntry
+	nil, // 40: quark.ServerMessage.TeamChanged.TeamsEntry
 }
 var file_proto_room_proto_depIdxs = []int32{
-	5,  // 0: quark.ClientMessage.joinRoom:type_name -> quark.ClientMessage.JoinRoomCommand
-	6,  // 1: quark.ClientMessage.sendMessage:type_name -> quark.ClientMessage.SendMessageCommand
-	7,  // 2: quark.ClientMessage.leaveRoom:type_name -> quark.ClientMessage.LeaveRoomCommand
-	8,  // 3: quark.ServerMessage.onCommandFailed:type_name -> quark.ServerMessage.CommandError
-	9,  // 4: quark.ServerMessage.onJoinRoomSuccess:type_name -> quark.ServerMessage.JoinRoomSuccess
-	10, // 5: quark.ServerMessage.onLeaveRoomSuccess:type_name -> quark.ServerMessage.LeaveRoomSuccess
-	11, // 6: quark.ServerMessage.onMessageReceived:type_name -> quark.ServerMessage.ReceivedMessageEvent
-	12, // 7: quark.ServerMessage.onJoinRoom:type_name -> quark.ServerMessage.JoinRoom
-	13, // 8: quark.ServerMessage.onLeaveRoom:type_name -> quark.ServerMessage.LeaveRoom
-	3,  // 9: quark.ClientMessage.SendMessageCommand.message:type_name -> quark.Message
-	5,  // 10: quark.ServerMessage.CommandError.joinRoom:type_name -> quark.ClientMessage.JoinRoomCommand
-	6,  // 11: quark.ServerMessage.CommandError.sendMessage:type_name -> quark.ClientMessage.SendMessageCommand
-	7,  // 12: quark.ServerMessage.CommandError.leaveRoom:type_name -> quark.ClientMessage.LeaveRoomCommand
-	3,  // 13: quark.ServerMessage.ReceivedMessageEvent.message:type_name -> quark.Message
-	0,  // 14: quark.Room.CreateRoom:input_type -> quark.CreateRoomRequest
-	2,  // 15: quark.Room.Service:input_type -> quark.ClientMessage
-	1,  // 16: quark.Room.CreateRoom:output_type -> quark.CreateRoomResponse
-	4,  // 17: quark.Room.Service:output_type -> quark.ServerMessage
-	16, // [16:18] is the sub-list for method output_type
-	14, // [14:16] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	4,  // 0: quark.CreateRoomRequest.roomOptions:type_name -> quark.RoomOptions
+	9,  // 1: quark.RoomOptions.properties:type_name -> quark.RoomOptions.PropertiesEntry
+	10, // 2: quark.RoomOptions.userTeams:type_name -> quark.RoomOptions.UserTeamsEntry
+	11, // 3: quark.ClientMessage.joinRoom:type_name -> quark.ClientMessage.JoinRoomCommand
+	12, // 4: quark.ClientMessage.sendMessage:type_name -> quark.ClientMessage.SendMessageCommand
+	13, // 5: quark.ClientMessage.leaveRoom:type_name -> quark.ClientMessage.LeaveRoomCommand
+	14, // 6: quark.ClientMessage.changeTeam:type_name -> quark.ClientMessage.ChangeTeamCommand
+	15, // 7: quark.ClientMessage.swapTeams:type_name -> quark.ClientMessage.SwapTeamsCommand
+	16, // 8: quark.ClientMessage.sendRequest:type_name -> quark.ClientMessage.SendRequestCommand
+	17, // 9: quark.ClientMessage.sendResponse:type_name -> quark.ClientMessage.SendResponseCommand
+	18, // 10: quark.ClientMessage.ping:type_name -> quark.ClientMessage.PingCommand
+	19, // 11: quark.ClientMessage.pong:type_name -> quark.ClientMessage.PongCommand
+	20, // 12: quark.ServerMessage.onCommandFailed:type_name -> quark.ServerMessage.CommandError
+	21, // 13: quark.ServerMessage.onJoinRoomSuccess:type_name -> quark.ServerMessage.JoinRoomSuccess
+	22, // 14: quark.ServerMessage.onLeaveRoomSuccess:type_name -> quark.ServerMessage.LeaveRoomSuccess
+	23, // 15: quark.ServerMessage.onMessageAck:type_name -> quark.ServerMessage.MessageAck
+	24, // 16: quark.ServerMessage.onMessageReceived:type_name -> quark.ServerMessage.ReceivedMessageEvent
+	27, // 17: quark.ServerMessage.onJoinRoom:type_name -> quark.ServerMessage.JoinRoom
+	28, // 18: quark.ServerMessage.onLeaveRoom:type_name -> quark.ServerMessage.LeaveRoom
+	33, // 19: quark.ServerMessage.onTeamChanged:type_name -> quark.ServerMessage.TeamChanged
+	25, // 20: quark.ServerMessage.onRequestReceived:type_name -> quark.ServerMessage.ReceivedRequestEvent
+	26, // 21: quark.ServerMessage.onResponseReceived:type_name -> quark.ServerMessage.ReceivedResponseEvent
+	30, // 22: quark.ServerMessage.onPing:type_name -> quark.ServerMessage.Ping
+	31, // 23: quark.ServerMessage.onPong:type_name -> quark.ServerMessage.Pong
+	32, // 24: quark.ServerMessage.onActorPropertiesChanged:type_name -> quark.ServerMessage.ActorPropertiesChanged
+	29, // 25: quark.ServerMessage.onRoomClosed:type_name -> quark.ServerMessage.RoomClosed
+	34, // 26: quark.ServerMessage.onBatch:type_name -> quark.ServerMessage.Batch
+	7,  // 27: quark.ClientMessage.SendMessageCommand.message:type_name -> quark.Message
+	0,  // 28: quark.ClientMessage.SendMessageCommand.target:type_name -> quark.ClientMessage.SendMessageCommand.Target
+	7,  // 29: quark.ClientMessage.SendRequestCommand.message:type_name -> quark.Message
+	7,  // 30: quark.ClientMessage.SendResponseCommand.message:type_name -> quark.Message
+	1,  // 31: quark.ServerMessage.CommandError.code:type_name -> quark.ServerMessage.CommandError.Code
+	35, // 32: quark.ServerMessage.CommandError.details:type_name -> quark.ServerMessage.CommandError.Details
+	11, // 33: quark.ServerMessage.CommandError.joinRoom:type_name -> quark.ClientMessage.JoinRoomCommand
+	12, // 34: quark.ServerMessage.CommandError.sendMessage:type_name -> quark.ClientMessage.SendMessageCommand
+	13, // 35: quark.ServerMessage.CommandError.leaveRoom:type_name -> quark.ClientMessage.LeaveRoomCommand
+	14, // 36: quark.ServerMessage.CommandError.changeTeam:type_name -> quark.ClientMessage.ChangeTeamCommand
+	15, // 37: quark.ServerMessage.CommandError.swapTeams:type_name -> quark.ClientMessage.SwapTeamsCommand
+	16, // 38: quark.ServerMessage.CommandError.sendRequest:type_name -> quark.ClientMessage.SendRequestCommand
+	17, // 39: quark.ServerMessage.CommandError.sendResponse:type_name -> quark.ClientMessage.SendResponseCommand
+	7,  // 40: quark.ServerMessage.ReceivedMessageEvent.message:type_name -> quark.Message
+	7,  // 41: quark.ServerMessage.ReceivedRequestEvent.message:type_name -> quark.Message
+	7,  // 42: quark.ServerMessage.ReceivedResponseEvent.message:type_name -> quark.Message
+	37, // 43: quark.ServerMessage.JoinRoom.teams:type_name -> quark.ServerMessage.JoinRoom.TeamsEntry
+	38, // 44: quark.ServerMessage.LeaveRoom.teams:type_name -> quark.ServerMessage.LeaveRoom.TeamsEntry
+	2,  // 45: quark.ServerMessage.LeaveRoom.reason:type_name -> quark.ServerMessage.LeaveRoom.Reason
+	2,  // 46: quark.ServerMessage.RoomClosed.reason:type_name -> quark.ServerMessage.LeaveRoom.Reason
+	39, // 47: quark.ServerMessage.ActorPropertiesChanged.properties:type_name -> quark.ServerMessage.ActorPropertiesChanged.PropertiesEntry
+	40, // 48: quark.ServerMessage.TeamChanged.teams:type_name -> quark.ServerMessage.TeamChanged.TeamsEntry
+	8,  // 49: quark.ServerMessage.Batch.messages:type_name -> quark.ServerMessage
+	36, // 50: quark.ServerMessage.CommandError.Details.metadata:type_name -> quark.ServerMessage.CommandError.Details.MetadataEntry
+	3,  // 51: quark.Room.CreateRoom:input_type -> quark.CreateRoomRequest
+	6,  // 52: quark.Room.Service:input_type -> quark.ClientMessage
+	5,  // 53: quark.Room.CreateRoom:output_type -> quark.CreateRoomResponse
+	8,  // 54: quark.Room.Service:output_type -> quark.ServerMessage
+	53, // [53:55] is the sub-list for method output_type
+	51, // [51:53] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_proto_room_proto_init() }
@@ -1097,7 +3077,7 @@ func file_proto_room_proto_init() {
 			}
 		}
 		file_proto_room_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateRoomResponse); i {
+			switch v := v.(*RoomOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1109,7 +3089,7 @@ func file_proto_room_proto_init() {
 			}
 		}
 		file_proto_room_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientMessage); i {
+			switch v := v.(*CreateRoomResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1121,7 +3101,7 @@ func file_proto_room_proto_init() {
 			}
 		}
 		file_proto_room_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Message); i {
+			switch v := v.(*ClientMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1133,7 +3113,7 @@ func file_proto_room_proto_init() {
 			}
 		}
 		file_proto_room_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerMessage); i {
+			switch v := v.(*Message); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1145,6 +3125,18 @@ func file_proto_room_proto_init() {
 			}
 		}
 		file_proto_room_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ClientMessage_JoinRoomCommand); i {
 			case 0:
 				return &v.state
@@ -1156,7 +3148,7 @@ func file_proto_room_proto_init() {
 				return nil
 			}
 		}
-		file_proto_room_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_room_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ClientMessage_SendMessageCommand); i {
 			case 0:
 				return &v.state
@@ -1168,7 +3160,7 @@ func file_proto_room_proto_init() {
 				return nil
 			}
 		}
-		file_proto_room_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_room_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ClientMessage_LeaveRoomCommand); i {
 			case 0:
 				return &v.state
@@ -1180,7 +3172,79 @@ func file_proto_room_proto_init() {
 				return nil
 			}
 		}
-		file_proto_room_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_room_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_ChangeTeamCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_SwapTeamsCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_SendRequestCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_SendResponseCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_PingCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_PongCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerMessage_CommandError); i {
 			case 0:
 				return &v.state
@@ -1192,7 +3256,7 @@ func file_proto_room_proto_init() {
 				return nil
 			}
 		}
-		file_proto_room_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_room_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerMessage_JoinRoomSuccess); i {
 			case 0:
 				return &v.state
@@ -1204,7 +3268,7 @@ func file_proto_room_proto_init() {
 				return nil
 			}
 		}
-		file_proto_room_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_room_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerMessage_LeaveRoomSuccess); i {
 			case 0:
 				return &v.state
@@ -1216,7 +3280,19 @@ func file_proto_room_proto_init() {
 				return nil
 			}
 		}
-		file_proto_room_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_room_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_MessageAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerMessage_ReceivedMessageEvent); i {
 			case 0:
 				return &v.state
@@ -1228,7 +3304,31 @@ func file_proto_room_proto_init() {
 				return nil
 			}
 		}
-		file_proto_room_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_room_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_ReceivedRequestEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_ReceivedResponseEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerMessage_JoinRoom); i {
 			case 0:
 				return &v.state
@@ -1240,7 +3340,7 @@ func file_proto_room_proto_init() {
 				return nil
 			}
 		}
-		file_proto_room_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_room_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerMessage_LeaveRoom); i {
 			case 0:
 				return &v.state
@@ -1252,37 +3352,141 @@ func file_proto_room_proto_init() {
 				return nil
 			}
 		}
+		file_proto_room_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_RoomClosed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_Ping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_Pong); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_ActorPropertiesChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_TeamChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_Batch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerMessage_CommandError_Details); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_proto_room_proto_msgTypes[2].OneofWrappers = []interface{}{
+	file_proto_room_proto_msgTypes[3].OneofWrappers = []interface{}{
 		(*ClientMessage_JoinRoom)(nil),
 		(*ClientMessage_SendMessage)(nil),
 		(*ClientMessage_LeaveRoom)(nil),
-	}
-	file_proto_room_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*ClientMessage_ChangeTeam)(nil),
+		(*ClientMessage_SwapTeams)(nil),
+		(*ClientMessage_SendRequest)(nil),
+		(*ClientMessage_SendResponse)(nil),
+		(*ClientMessage_Ping)(nil),
+		(*ClientMessage_Pong)(nil),
+	}
+	file_proto_room_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*ServerMessage_OnCommandFailed)(nil),
 		(*ServerMessage_OnJoinRoomSuccess)(nil),
 		(*ServerMessage_OnLeaveRoomSuccess)(nil),
+		(*ServerMessage_OnMessageAck)(nil),
 		(*ServerMessage_OnMessageReceived)(nil),
 		(*ServerMessage_OnJoinRoom)(nil),
 		(*ServerMessage_OnLeaveRoom)(nil),
-	}
-	file_proto_room_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*ServerMessage_OnTeamChanged)(nil),
+		(*ServerMessage_OnRequestReceived)(nil),
+		(*ServerMessage_OnResponseReceived)(nil),
+		(*ServerMessage_OnPing)(nil),
+		(*ServerMessage_OnPong)(nil),
+		(*ServerMessage_OnActorPropertiesChanged)(nil),
+		(*ServerMessage_OnRoomClosed)(nil),
+		(*ServerMessage_OnBatch)(nil),
+	}
+	file_proto_room_proto_msgTypes[17].OneofWrappers = []interface{}{
 		(*ServerMessage_CommandError_JoinRoom)(nil),
 		(*ServerMessage_CommandError_SendMessage)(nil),
 		(*ServerMessage_CommandError_LeaveRoom)(nil),
+		(*ServerMessage_CommandError_ChangeTeam)(nil),
+		(*ServerMessage_CommandError_SwapTeams)(nil),
+		(*ServerMessage_CommandError_SendRequest)(nil),
+		(*ServerMessage_CommandError_SendResponse)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_room_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      3,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_room_proto_goTypes,
 		DependencyIndexes: file_proto_room_proto_depIdxs,
+		EnumInfos:         file_proto_room_proto_enumTypes,
 		MessageInfos:      file_proto_room_proto_msgTypes,
 	}.Build()
 	File_proto_room_proto = out.File